@@ -0,0 +1,485 @@
+// Package config centraliza la configuración de la aplicación.
+//
+// Cada valor se resuelve en este orden, donde el último gana: valor por
+// defecto, archivo de configuración (formato TOML, ver config.example.toml),
+// variable de entorno y flag de línea de comandos. El archivo se indica con
+// -config o con CONFIG_FILE.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"tp-web/logging"
+)
+
+type DBConfig struct {
+	// URL es una cadena de conexión completa (postgres://...); si está
+	// definida se ignoran Host, Port, User, Password, Name y SSLMode.
+	URL      string
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+	SSLMode  string
+
+	// Pool de conexiones
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ConnectTimeout es cuánto se reintenta conectar al arrancar antes de
+	// rendirse.
+	ConnectTimeout time.Duration
+}
+
+type HTTPConfig struct {
+	Host              string
+	Port              string
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	ShutdownTimeout   time.Duration
+}
+
+type StorageConfig struct {
+	// Carpeta desde la que se sirven las imágenes en /img/
+	ImageDir string
+}
+
+type AuthConfig struct {
+	// Usuario y contraseña para las peticiones que modifican datos; si User
+	// está vacío la autenticación queda desactivada.
+	User     string
+	Password string
+}
+
+type LogConfig struct {
+	// Level es el nivel inicial (debug, info, warn o error); se puede cambiar
+	// en tiempo de ejecución.
+	Level string
+	// Format es "json" o "text".
+	Format string
+}
+
+type TracingConfig struct {
+	// Endpoint es la URL del colector OTLP/HTTP (por ejemplo
+	// http://localhost:4318); vacío desactiva las trazas.
+	Endpoint string
+	// Headers se agregan a cada envío, con el formato "clave=valor,clave2=valor2".
+	Headers     string
+	ServiceName string
+}
+
+type PricesConfig struct {
+	// Source es la fuente de precios de los juegos deseados ("fake"); vacío
+	// desactiva la consulta automática y los precios se cargan a mano.
+	Source string
+	// Interval es cada cuánto se consultan los precios.
+	Interval time.Duration
+}
+
+type MetadataConfig struct {
+	// Provider es el catálogo donde se buscan los datos de los juegos al
+	// darlos de alta ("fake"); vacío desactiva la búsqueda.
+	Provider string
+}
+
+type CalendarConfig struct {
+	// UpcomingDays es cuántos días hacia adelante abarcan los próximos
+	// lanzamientos de la lista de deseados.
+	UpcomingDays int
+}
+
+type Config struct {
+	DB       DBConfig
+	HTTP     HTTPConfig
+	Storage  StorageConfig
+	Auth     AuthConfig
+	Log      LogConfig
+	Tracing  TracingConfig
+	Prices   PricesConfig
+	Metadata MetadataConfig
+	Calendar CalendarConfig
+}
+
+// Default devuelve la configuración con los valores por defecto.
+func Default() Config {
+	return Config{
+		DB: DBConfig{
+			Host:            "localhost",
+			Port:            "5432",
+			SSLMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			ConnectTimeout:  30 * time.Second,
+		},
+		HTTP: HTTPConfig{
+			Port:              "8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   20 * time.Second,
+		},
+		Storage: StorageConfig{
+			ImageDir: "img",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			ServiceName: "tp-web",
+		},
+		Prices: PricesConfig{
+			Interval: 6 * time.Hour,
+		},
+		Calendar: CalendarConfig{
+			UpcomingDays: 30,
+		},
+	}
+}
+
+// setting describe un valor configurable: su clave en el archivo ("seccion.clave"),
+// su variable de entorno y su flag.
+type setting struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+	get   func() string
+	set   func(string) error
+	// redact oculta los secretos del valor al mostrarlo; nil si no hay.
+	redact func(string) string
+}
+
+func str(key, env, usage string, p *string) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func() string { return *p },
+		set: func(v string) error { *p = v; return nil },
+	}
+}
+
+func secret(key, env, usage string, p *string) setting {
+	s := str(key, env, usage, p)
+	s.redact = func(string) string { return "****" }
+	return s
+}
+
+// dbURL es un str cuya contraseña se oculta al mostrarlo.
+func dbURL(key, env, usage string, p *string) setting {
+	s := str(key, env, usage, p)
+	s.redact = func(v string) string {
+		u, err := url.Parse(v)
+		if err != nil {
+			return "****"
+		}
+		return u.Redacted()
+	}
+	return s
+}
+
+func integer(key, env, usage string, p *int) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func() string { return strconv.Itoa(*p) },
+		set: func(v string) error {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			*p = n
+			return nil
+		},
+	}
+}
+
+func dur(key, env, usage string, p *time.Duration) setting {
+	return setting{
+		key: key, env: env, usage: usage,
+		get: func() string { return p.String() },
+		set: func(v string) error {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return err
+			}
+			*p = d
+			return nil
+		},
+	}
+}
+
+// settings lista todos los valores configurables de c. El flag de cada uno es
+// su clave con "-" en lugar de "." y "_" (http.read_timeout → -http-read-timeout).
+func (c *Config) settings() []setting {
+	settings := []setting{
+		dbURL("db.url", "DATABASE_URL", "cadena de conexión completa, reemplaza a los demás db.*", &c.DB.URL),
+		str("db.host", "DB_HOST", "host de Postgres", &c.DB.Host),
+		str("db.port", "DB_PORT", "puerto de Postgres", &c.DB.Port),
+		str("db.user", "DB_USER", "usuario de Postgres", &c.DB.User),
+		secret("db.password", "DB_PASSWORD", "contraseña de Postgres", &c.DB.Password),
+		str("db.name", "DB_NAME", "nombre de la base de datos", &c.DB.Name),
+		str("db.ssl_mode", "DB_SSL_MODE", "sslmode de la conexión", &c.DB.SSLMode),
+		integer("db.max_open_conns", "DB_MAX_OPEN_CONNS", "máximo de conexiones abiertas", &c.DB.MaxOpenConns),
+		integer("db.max_idle_conns", "DB_MAX_IDLE_CONNS", "máximo de conexiones inactivas en el pool", &c.DB.MaxIdleConns),
+		dur("db.conn_max_lifetime", "DB_CONN_MAX_LIFETIME", "tiempo máximo de vida de una conexión", &c.DB.ConnMaxLifetime),
+		dur("db.conn_max_idle_time", "DB_CONN_MAX_IDLE_TIME", "tiempo máximo que una conexión puede estar inactiva", &c.DB.ConnMaxIdleTime),
+		dur("db.connect_timeout", "DB_CONNECT_TIMEOUT", "cuánto reintentar la conexión al arrancar", &c.DB.ConnectTimeout),
+
+		str("http.host", "HTTP_HOST", "dirección en la que escucha el servidor (vacío = todas)", &c.HTTP.Host),
+		str("http.port", "HTTP_PORT", "puerto del servidor HTTP", &c.HTTP.Port),
+		dur("http.read_header_timeout", "HTTP_READ_HEADER_TIMEOUT", "tiempo máximo para leer los headers", &c.HTTP.ReadHeaderTimeout),
+		dur("http.read_timeout", "HTTP_READ_TIMEOUT", "tiempo máximo para leer la petición", &c.HTTP.ReadTimeout),
+		dur("http.write_timeout", "HTTP_WRITE_TIMEOUT", "tiempo máximo para escribir la respuesta", &c.HTTP.WriteTimeout),
+		dur("http.idle_timeout", "HTTP_IDLE_TIMEOUT", "tiempo máximo de una conexión keep-alive inactiva", &c.HTTP.IdleTimeout),
+		dur("http.shutdown_timeout", "HTTP_SHUTDOWN_TIMEOUT", "espera máxima de las peticiones en curso al apagar", &c.HTTP.ShutdownTimeout),
+
+		str("storage.image_dir", "STORAGE_IMAGE_DIR", "carpeta de imágenes servida en /img/", &c.Storage.ImageDir),
+
+		str("auth.user", "AUTH_USER", "usuario para crear/modificar/eliminar y para /admin/ (vacío = sin autenticación ni /admin/)", &c.Auth.User),
+		secret("auth.password", "AUTH_PASSWORD", "contraseña para crear/modificar/eliminar", &c.Auth.Password),
+
+		str("log.level", "LOG_LEVEL", "nivel de log inicial: debug, info, warn o error", &c.Log.Level),
+		str("log.format", "LOG_FORMAT", "formato de los logs: json o text", &c.Log.Format),
+
+		str("tracing.endpoint", "OTEL_EXPORTER_OTLP_ENDPOINT", "colector OTLP/HTTP para las trazas (vacío = desactivadas)", &c.Tracing.Endpoint),
+		secret("tracing.headers", "OTEL_EXPORTER_OTLP_HEADERS", "headers para el colector: clave=valor,clave2=valor2", &c.Tracing.Headers),
+		str("tracing.service_name", "OTEL_SERVICE_NAME", "nombre del servicio en las trazas", &c.Tracing.ServiceName),
+
+		str("prices.source", "PRICES_SOURCE", "fuente de precios de los juegos deseados: fake (vacío = solo carga manual)", &c.Prices.Source),
+		dur("prices.interval", "PRICES_INTERVAL", "cada cuánto se consultan los precios", &c.Prices.Interval),
+
+		str("metadata.provider", "METADATA_PROVIDER", "catálogo donde buscar los datos de los juegos: fake (vacío = sin búsqueda)", &c.Metadata.Provider),
+
+		integer("calendar.upcoming_days", "CALENDAR_UPCOMING_DAYS", "días hacia adelante de los próximos lanzamientos deseados", &c.Calendar.UpcomingDays),
+	}
+	for i := range settings {
+		settings[i].flag = strings.NewReplacer(".", "-", "_", "-").Replace(settings[i].key)
+	}
+	return settings
+}
+
+// Load arma la configuración a partir de los valores por defecto, el archivo
+// de configuración, las variables de entorno y los flags de args.
+func Load(args []string) (Config, error) {
+	cfg := Default()
+	settings := cfg.settings()
+
+	fs := flag.NewFlagSet("tp-web", flag.ContinueOnError)
+	configFile := fs.String("config", os.Getenv("CONFIG_FILE"), "archivo de configuración TOML")
+	flagValues := map[string]*string{}
+	for _, s := range settings {
+		flagValues[s.flag] = fs.String(s.flag, "", s.usage+" ("+s.env+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	if *configFile != "" {
+		values, err := readFile(*configFile)
+		if err != nil {
+			return cfg, err
+		}
+		for _, s := range settings {
+			if v, ok := values[s.key]; ok {
+				if err := s.set(v); err != nil {
+					return cfg, fmt.Errorf("%s: %s: %w", *configFile, s.key, err)
+				}
+				delete(values, s.key)
+			}
+		}
+		for key := range values {
+			return cfg, fmt.Errorf("%s: clave desconocida %q", *configFile, key)
+		}
+	}
+
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := s.set(v); err != nil {
+				return cfg, fmt.Errorf("%s: %w", s.env, err)
+			}
+		}
+	}
+
+	var flagErr error
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings {
+			if s.flag == f.Name {
+				if err := s.set(*flagValues[s.flag]); err != nil && flagErr == nil {
+					flagErr = fmt.Errorf("-%s: %w", s.flag, err)
+				}
+			}
+		}
+	})
+	if flagErr != nil {
+		return cfg, flagErr
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate revisa que estén los valores obligatorios y que tengan sentido.
+func (c Config) Validate() error {
+	var errs []error
+	required := func(name, v string) {
+		if v == "" {
+			errs = append(errs, fmt.Errorf("%s es obligatorio", name))
+		}
+	}
+	port := func(name, v string) {
+		if n, err := strconv.Atoi(v); err != nil || n < 1 || n > 65535 {
+			errs = append(errs, fmt.Errorf("%s debe ser un puerto válido, es %q", name, v))
+		}
+	}
+	positive := func(name string, d time.Duration) {
+		if d <= 0 {
+			errs = append(errs, fmt.Errorf("%s debe ser mayor a cero", name))
+		}
+	}
+
+	if c.DB.URL != "" {
+		if u, err := url.Parse(c.DB.URL); err != nil || (u.Scheme != "postgres" && u.Scheme != "postgresql") {
+			errs = append(errs, errors.New("db.url debe ser una URL postgres://"))
+		}
+	} else {
+		required("db.host", c.DB.Host)
+		port("db.port", c.DB.Port)
+		required("db.user", c.DB.User)
+		required("db.name", c.DB.Name)
+		sslModes := []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+		if !slices.Contains(sslModes, c.DB.SSLMode) {
+			errs = append(errs, fmt.Errorf("db.ssl_mode debe ser uno de %v, es %q", sslModes, c.DB.SSLMode))
+		}
+	}
+	if c.DB.MaxOpenConns < 1 {
+		errs = append(errs, errors.New("db.max_open_conns debe ser mayor a cero"))
+	}
+	if c.DB.MaxIdleConns < 0 || c.DB.MaxIdleConns > c.DB.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("db.max_idle_conns debe estar entre 0 y db.max_open_conns (%d)", c.DB.MaxOpenConns))
+	}
+	positive("db.conn_max_lifetime", c.DB.ConnMaxLifetime)
+	positive("db.conn_max_idle_time", c.DB.ConnMaxIdleTime)
+	positive("db.connect_timeout", c.DB.ConnectTimeout)
+
+	port("http.port", c.HTTP.Port)
+	positive("http.read_header_timeout", c.HTTP.ReadHeaderTimeout)
+	positive("http.read_timeout", c.HTTP.ReadTimeout)
+	positive("http.write_timeout", c.HTTP.WriteTimeout)
+	positive("http.idle_timeout", c.HTTP.IdleTimeout)
+	positive("http.shutdown_timeout", c.HTTP.ShutdownTimeout)
+
+	required("storage.image_dir", c.Storage.ImageDir)
+
+	if c.Auth.User != "" && c.Auth.Password == "" {
+		errs = append(errs, errors.New("auth.password es obligatorio si se define auth.user"))
+	}
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, fmt.Errorf("log.level: %w", err))
+	}
+	if c.Log.Format != "json" && c.Log.Format != "text" {
+		errs = append(errs, fmt.Errorf("log.format debe ser json o text, es %q", c.Log.Format))
+	}
+
+	if c.Tracing.Endpoint != "" {
+		if u, err := url.Parse(c.Tracing.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("tracing.endpoint debe ser una URL http(s), es %q", c.Tracing.Endpoint))
+		}
+		required("tracing.service_name", c.Tracing.ServiceName)
+	}
+
+	if c.Prices.Source != "" && c.Prices.Source != "fake" {
+		errs = append(errs, fmt.Errorf("prices.source debe ser fake o estar vacío, es %q", c.Prices.Source))
+	}
+	positive("prices.interval", c.Prices.Interval)
+
+	if c.Metadata.Provider != "" && c.Metadata.Provider != "fake" {
+		errs = append(errs, fmt.Errorf("metadata.provider debe ser fake o estar vacío, es %q", c.Metadata.Provider))
+	}
+
+	if c.Calendar.UpcomingDays < 1 || c.Calendar.UpcomingDays > 365 {
+		errs = append(errs, fmt.Errorf("calendar.upcoming_days debe estar entre 1 y 365, es %d", c.Calendar.UpcomingDays))
+	}
+
+	return errors.Join(errs...)
+}
+
+// LogValue permite loguear la configuración con slog, con los secretos ocultos.
+func (c Config) LogValue() slog.Value {
+	var attrs []slog.Attr
+	for _, s := range c.settings() {
+		v := s.get()
+		if s.redact != nil && v != "" {
+			v = s.redact(v)
+		}
+		attrs = append(attrs, slog.String(s.key, v))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// readFile lee un archivo TOML simple: secciones [db], [http], ... con pares
+// clave = valor. Los valores pueden ir entre comillas; se admiten comentarios
+// con #. Devuelve las claves como "seccion.clave".
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	section := ""
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: se esperaba clave = valor", path, i+1)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+		value = strings.TrimSpace(value)
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// stripComment quita un comentario # que no esté dentro de comillas.
+func stripComment(line string) string {
+	inQuotes := false
+	for i, r := range line {
+		switch r {
+		case '"':
+			inQuotes = !inQuotes
+		case '#':
+			if !inQuotes {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
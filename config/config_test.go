@@ -0,0 +1,112 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"tp-web/config"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeFile(t, `
+# comentario
+[db]
+host = "filehost"
+user = "fileuser"
+name = "tpwebdb"
+password = "secreto # no es comentario"
+
+[http]
+port = "9000"
+read_timeout = "7s"
+`)
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_HOST", "envhost")
+	t.Setenv("HTTP_PORT", "9100")
+
+	cfg, err := config.Load([]string{"-config", path, "-http-port", "9200"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, got, want string
+	}{
+		{"default", cfg.DB.Port, "5432"},
+		{"file", cfg.DB.User, "fileuser"},
+		{"file quoted with #", cfg.DB.Password, "secreto # no es comentario"},
+		{"env over file", cfg.DB.Host, "envhost"},
+		{"flag over env", cfg.HTTP.Port, "9200"},
+		{"duration", cfg.HTTP.ReadTimeout.String(), (7 * time.Second).String()},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	cfg.DB.URL = "postgres://userdb:secreto@db:5432/tpwebdb"
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", "config", cfg)
+	redacted := buf.String()
+	if strings.Contains(redacted, "secreto") || !strings.Contains(redacted, "config.db.password=****") || !strings.Contains(redacted, "userdb:xxxxx@db") {
+		t.Errorf("password not redacted:\n%s", redacted)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_USER", "userdb")
+	t.Setenv("DB_NAME", "tpwebdb")
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		wantErr string
+	}{
+		{"valid", nil, nil, ""},
+		{"invalid port", []string{"-http-port", "80800"}, nil, "http.port"},
+		{"invalid duration", nil, map[string]string{"HTTP_READ_TIMEOUT": "15"}, "HTTP_READ_TIMEOUT"},
+		{"zero timeout", []string{"-http-idle-timeout", "0s"}, nil, "http.idle_timeout"},
+		{"auth without password", []string{"-auth-user", "admin"}, nil, "auth.password"},
+		{"invalid ssl mode", nil, map[string]string{"DB_SSL_MODE": "on"}, "db.ssl_mode"},
+		{"url replaces db fields", nil, map[string]string{"DB_USER": "", "DATABASE_URL": "postgres://u:p@db/tpwebdb"}, ""},
+		{"invalid url", nil, map[string]string{"DATABASE_URL": "mysql://u@db/x"}, "db.url"},
+		{"idle above open", []string{"-db-max-open-conns", "2", "-db-max-idle-conns", "5"}, nil, "db.max_idle_conns"},
+		{"unknown price source", nil, map[string]string{"PRICES_SOURCE": "steam"}, "prices.source"},
+		{"unknown metadata provider", nil, map[string]string{"METADATA_PROVIDER": "igdb"}, "metadata.provider"},
+		{"upcoming days out of range", []string{"-calendar-upcoming-days", "0"}, nil, "calendar.upcoming_days"},
+		{"unknown file key", []string{"-config", writeFile(t, "[db]\nhots = \"x\"\n")}, nil, "db.hots"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			_, err := config.Load(tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,29 +1,114 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"os"
+	"log/slog"
+	"time"
+	"tp-web/config"
+	datos "tp-web/db/sqlc"
 
 	_ "github.com/lib/pq"
 )
 
-func InitDb() (*sql.DB, error) {
-	// Leer variables de entorno
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
-	dbSSLMode := os.Getenv("DB_SSL_MODE")
-
-	// Conexión a la base de datos
-	connStr := fmt.Sprintf(
+// SchemaVersion es la versión del esquema que espera este código: la de la
+// última migración de db/migrations, que Migrate registra en schema_version.
+const SchemaVersion = 9
+
+// Espera entre reintentos de conexión: se duplica en cada intento hasta maxBackoff.
+const (
+	initialBackoff = 250 * time.Millisecond
+	maxBackoff     = 5 * time.Second
+)
+
+// InitDb abre el pool de conexiones con los límites de cfg y verifica la
+// conexión con un ping. Si la base todavía no responde (por ejemplo mientras
+// arranca el contenedor) reintenta con espera exponencial hasta
+// cfg.ConnectTimeout o hasta que se cancele ctx.
+func InitDb(ctx context.Context, cfg config.DBConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", connString(cfg))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ConnectTimeout)
+	defer cancel()
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err = db.PingContext(ctx)
+		if err == nil {
+			return db, nil
+		}
+		slog.WarnContext(ctx, "No se pudo conectar a la base de datos, reintentando", "intento", attempt, "espera", backoff.String(), "err", err)
+
+		select {
+		case <-ctx.Done():
+			db.Close()
+			return nil, fmt.Errorf("no se pudo conectar a la base de datos después de %d intentos: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// Ready verifica que la base responda y que su esquema esté en SchemaVersion.
+func Ready(ctx context.Context, db *sql.DB) error {
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("la base de datos no responde: %w", err)
+	}
+	version, err := datos.New(db).GetSchemaVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("no se pudo leer la versión del esquema: %w", err)
+	}
+	if version != SchemaVersion {
+		return fmt.Errorf("el esquema está en la versión %d, se espera la %d", version, SchemaVersion)
+	}
+	return nil
+}
+
+// connString arma la cadena de conexión: cfg.URL si está definida, si no a
+// partir de los campos sueltos.
+func connString(cfg config.DBConfig) string {
+	if cfg.URL != "" {
+		return cfg.URL
+	}
+	return fmt.Sprintf(
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		dbHost, dbPort, dbUser, dbPassword, dbName, dbSSLMode,
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode,
 	)
+}
 
-	db, err := sql.Open("postgres", connStr)
+// PoolStats es la vista en JSON de sql.DBStats.
+type PoolStats struct {
+	MaxOpenConnections int    `json:"max_open_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
 
-	return db, err
+// Stats devuelve el estado actual del pool de db.
+func Stats(db *sql.DB) PoolStats {
+	s := db.Stats()
+	return PoolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDuration:       s.WaitDuration.String(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
 }
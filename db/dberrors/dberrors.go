@@ -0,0 +1,134 @@
+package dberrors
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"tp-web/i18n"
+
+	"github.com/lib/pq"
+)
+
+// Error es la traducción de un error de la capa de datos a una respuesta HTTP
+// con un mensaje apto para mostrar al usuario.
+type Error struct {
+	Status int    `json:"-"`
+	Code   string `json:"code"`
+	Field  string `json:"field,omitempty"`
+	Key    string `json:"-"`
+}
+
+// Message devuelve el mensaje traducido al idioma pedido.
+func (e *Error) Message(lang string) string {
+	return i18n.Translate(lang, e.Key)
+}
+
+// Campos del formulario afectados por cada constraint con nombre conocido.
+var constraintFields = map[string]string{
+	"games_estado_check":                   "state",
+	"game_revisions_estado_check":          "state",
+	"games_plataforma_titulo_key":          "title",
+	"games_fecha_precision_check":          "release_precision",
+	"game_revisions_fecha_precision_check": "release_precision",
+	"series_name_key":                      "name",
+	"series_entries_series_id_fkey":        "series_id",
+	"series_entries_position_check":        "position",
+	"game_editions_edition_check":          "edition",
+}
+
+// Campos del formulario que corresponden a cada columna.
+var columnFields = map[string]string{
+	"titulo":          "title",
+	"descripcion":     "description",
+	"categoria":       "category",
+	"plataforma":      "platform",
+	"fecha":           "release_date",
+	"fecha_precision": "release_precision",
+	"estado":          "state",
+	"imagen":          "image",
+}
+
+// Translate convierte un error devuelto por las queries en un *Error. Los
+// errores desconocidos se traducen a un 500 genérico.
+func Translate(err error) *Error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return &Error{
+			Status: http.StatusNotFound,
+			Code:   "not_found",
+			Key:    "db.not_found",
+		}
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return unexpected()
+	}
+
+	field := constraintFields[pqErr.Constraint]
+	if field == "" {
+		field = columnFields[pqErr.Column]
+	}
+
+	switch pqErr.Code.Name() {
+	case "check_violation":
+		return &Error{
+			Status: http.StatusUnprocessableEntity,
+			Code:   "check_violation",
+			Field:  field,
+			Key:    "db.check_violation",
+		}
+	case "not_null_violation":
+		return &Error{
+			Status: http.StatusUnprocessableEntity,
+			Code:   "not_null_violation",
+			Field:  field,
+			Key:    "db.not_null_violation",
+		}
+	case "string_data_right_truncation":
+		return &Error{
+			Status: http.StatusUnprocessableEntity,
+			Code:   "string_data_right_truncation",
+			Field:  field,
+			Key:    "db.string_data_right_truncation",
+		}
+	case "unique_violation":
+		if field == "title" {
+			return &Error{
+				Status: http.StatusConflict,
+				Code:   "unique_violation",
+				Field:  field,
+				Key:    "db.unique_violation.title",
+			}
+		}
+		return &Error{
+			Status: http.StatusConflict,
+			Code:   "unique_violation",
+			Field:  field,
+			Key:    "db.unique_violation",
+		}
+	case "foreign_key_violation":
+		return &Error{
+			Status: http.StatusConflict,
+			Code:   "foreign_key_violation",
+			Field:  field,
+			Key:    "db.foreign_key_violation",
+		}
+	case "invalid_text_representation", "invalid_datetime_format", "datetime_field_overflow":
+		return &Error{
+			Status: http.StatusBadRequest,
+			Code:   "invalid_input",
+			Field:  field,
+			Key:    "db.invalid_input",
+		}
+	}
+
+	return unexpected()
+}
+
+func unexpected() *Error {
+	return &Error{
+		Status: http.StatusInternalServerError,
+		Code:   "unexpected",
+		Key:    "db.unexpected",
+	}
+}
@@ -0,0 +1,133 @@
+// Package dbtest levanta una base de datos Postgres descartable para los
+// tests de integración.
+//
+// Usa la instancia configurada con las mismas variables DB_* que la
+// aplicación, pero nunca toca DB_NAME: crea una base nueva con un nombre
+// aleatorio, le aplica db/schema y la borra al terminar el test. El usuario
+// necesita permiso CREATEDB. Si DB_HOST no está definida los tests se saltean.
+package dbtest
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+// New crea una base de datos vacía con el esquema aplicado y devuelve una
+// conexión a ella. La base se elimina en el Cleanup del test.
+func New(t testing.TB) *sql.DB {
+	t.Helper()
+
+	db := Empty(t)
+	if err := applySchema(db); err != nil {
+		t.Fatalf("dbtest: aplicar esquema: %v", err)
+	}
+	return db
+}
+
+// Empty es como New pero no aplica el esquema, para probar las migraciones.
+func Empty(t testing.TB) *sql.DB {
+	t.Helper()
+
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST no está definida; se saltean los tests de integración")
+	}
+
+	admin, err := sql.Open("postgres", connString(adminDBName()))
+	if err != nil {
+		t.Fatalf("dbtest: abrir conexión: %v", err)
+	}
+	defer admin.Close()
+	if err := admin.Ping(); err != nil {
+		t.Fatalf("dbtest: conectar a Postgres: %v", err)
+	}
+
+	name := "tpweb_test_" + randomSuffix()
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		t.Fatalf("dbtest: crear base %s: %v", name, err)
+	}
+
+	db, err := sql.Open("postgres", connString(name))
+	if err != nil {
+		t.Fatalf("dbtest: abrir %s: %v", name, err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+		admin, err := sql.Open("postgres", connString(adminDBName()))
+		if err != nil {
+			t.Errorf("dbtest: abrir conexión para borrar %s: %v", name, err)
+			return
+		}
+		defer admin.Close()
+		if _, err := admin.Exec("DROP DATABASE IF EXISTS " + name + " WITH (FORCE)"); err != nil {
+			t.Errorf("dbtest: borrar base %s: %v", name, err)
+		}
+	})
+
+	return db
+}
+
+// applySchema ejecuta los archivos de db/schema en orden alfabético, igual
+// que los lee sqlc.
+func applySchema(db *sql.DB) error {
+	files, err := filepath.Glob(filepath.Join(schemaDir(), "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		content, err := os.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		if _, err := db.Exec(string(content)); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(f), err)
+		}
+	}
+	return nil
+}
+
+// schemaDir ubica db/schema a partir de este archivo, así funciona desde
+// cualquier paquete que corra los tests.
+func schemaDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "schema")
+}
+
+// adminDBName es la base a la que se conecta para crear y borrar las bases
+// de prueba.
+func adminDBName() string {
+	if name := os.Getenv("DB_NAME"); name != "" {
+		return name
+	}
+	return "postgres"
+}
+
+func connString(dbName string) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		os.Getenv("DB_HOST"), getenv("DB_PORT", "5432"), os.Getenv("DB_USER"), os.Getenv("DB_PASSWORD"), dbName, getenv("DB_SSL_MODE", "disable"),
+	)
+}
+
+func getenv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func randomSuffix() string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
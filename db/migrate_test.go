@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"tp-web/db/dbtest"
+)
+
+func TestMigrationVersions(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Sin huecos ni repetidas, y la última es la que espera el código
+	for i, m := range migrations {
+		if m.version != i+1 {
+			t.Fatalf("%s: versión %d, se esperaba %d", m.name, m.version, i+1)
+		}
+	}
+	if n := len(migrations); n != SchemaVersion {
+		t.Fatalf("última migración = %d, SchemaVersion = %d", n, SchemaVersion)
+	}
+}
+
+func count(t *testing.T, db *sql.DB, query string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow(query).Scan(&n); err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	return n
+}
+
+func TestMigrate(t *testing.T) {
+	db := dbtest.Empty(t)
+	ctx := context.Background()
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ready(ctx, db); err != nil {
+		t.Fatalf("Ready: %v", err)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM games"); n != 4 {
+		t.Fatalf("juegos precargados = %d, want 4", n)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM series_entries"); n != 4 {
+		t.Fatalf("entregas precargadas = %d, want 4", n)
+	}
+
+	// Volver a migrar no cambia nada
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM schema_version"); n != SchemaVersion {
+		t.Fatalf("schema_version tiene %d filas, want %d", n, SchemaVersion)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM games"); n != 4 {
+		t.Fatalf("juegos después de migrar otra vez = %d, want 4", n)
+	}
+}
+
+// Una base creada con el init.sql original: solo la tabla games, sin
+// schema_version.
+func TestMigrateFromBaseline(t *testing.T) {
+	db := dbtest.Empty(t)
+	ctx := context.Background()
+
+	_, err := db.Exec(`CREATE TABLE public.games (
+    id SERIAL PRIMARY KEY,
+    titulo       VARCHAR(150) NOT NULL,
+    descripcion  VARCHAR(255) NOT NULL,
+    categoria    VARCHAR(50) NOT NULL,
+    fecha        DATE NOT NULL,
+    estado       VARCHAR(20) CHECK (estado IN ('none', 'deseado', 'comprado')) NOT NULL,
+    imagen       VARCHAR(50) NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+INSERT INTO public.games (titulo, descripcion, categoria, fecha, estado, imagen) VALUES
+('Hades','Roguelike','Accion','2020-09-17','comprado','img/hades.png'),
+('Celeste','Plataformas','Accion','2018-01-25','deseado','img/celeste.png');`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := Ready(ctx, db); err != nil {
+		t.Fatalf("Ready: %v", err)
+	}
+	// Se conservan los juegos, sin sumar los de ejemplo, y cada uno tiene su
+	// revisión inicial
+	if n := count(t, db, "SELECT COUNT(*) FROM games"); n != 2 {
+		t.Fatalf("juegos = %d, want 2", n)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM game_revisions WHERE revision = 1"); n != 2 {
+		t.Fatalf("revisiones iniciales = %d, want 2", n)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM games WHERE fecha_precision = 'day'"); n != 2 {
+		t.Fatalf("juegos con fecha_precision = day: %d, want 2", n)
+	}
+	if n := count(t, db, "SELECT COUNT(*) FROM series"); n != 0 {
+		t.Fatalf("series = %d, want 0", n)
+	}
+}
+
+// Las migraciones y db/schema (lo que lee sqlc) tienen que describir el mismo
+// esquema.
+func TestMigrationsMatchSchema(t *testing.T) {
+	migrated := dbtest.Empty(t)
+	if err := Migrate(context.Background(), migrated); err != nil {
+		t.Fatal(err)
+	}
+	reference := dbtest.New(t)
+
+	for _, query := range []string{
+		`SELECT table_name || '.' || column_name || ' ' || data_type || ' ' || is_nullable || ' ' || COALESCE(column_default, '')
+		FROM information_schema.columns WHERE table_schema = 'public'`,
+		`SELECT indexdef FROM pg_indexes WHERE schemaname = 'public'`,
+		`SELECT conrelid::regclass || ' ' || pg_get_constraintdef(oid) FROM pg_constraint WHERE connamespace = 'public'::regnamespace`,
+	} {
+		got, want := describe(t, migrated, query), describe(t, reference, query)
+		for _, d := range want {
+			if !slices.Contains(got, d) {
+				t.Errorf("falta en las migraciones: %s", d)
+			}
+		}
+		for _, d := range got {
+			if !slices.Contains(want, d) {
+				t.Errorf("sobra en las migraciones: %s", d)
+			}
+		}
+	}
+}
+
+func describe(t *testing.T, db *sql.DB, query string) []string {
+	t.Helper()
+	rows, err := db.Query(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
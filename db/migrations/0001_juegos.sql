@@ -0,0 +1,65 @@
+-- Juegos con su historial de revisiones y el título normalizado para
+-- detectar duplicados. Las bases creadas con el init.sql original ya tienen
+-- la tabla games (y quizás algo más), por eso todo usa IF NOT EXISTS.
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Título normalizado (sin mayúsculas, acentos ni espacios repetidos) para
+-- detectar duplicados. Se crea solo si falta: en las bases viejas la función
+-- es del superusuario y userdb no podría reemplazarla.
+DO $do$
+BEGIN
+    IF to_regprocedure('public.normalize_title(text)') IS NULL THEN
+        CREATE FUNCTION public.normalize_title(text) RETURNS text AS $$
+            SELECT regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, $1)), '\s+', ' ', 'g')
+        $$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+    END IF;
+END
+$do$;
+
+CREATE TABLE IF NOT EXISTS public.games (
+    id SERIAL PRIMARY KEY,
+    titulo       VARCHAR(150) NOT NULL,
+    descripcion  VARCHAR(255) NOT NULL,
+    categoria    VARCHAR(50) NOT NULL,
+    fecha        DATE NOT NULL,
+    estado       VARCHAR(20) CHECK (estado IN ('none', 'deseado', 'comprado')) NOT NULL,
+    imagen       VARCHAR(50) NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Un mismo título (ignorando mayúsculas y acentos) no puede cargarse dos veces
+CREATE UNIQUE INDEX IF NOT EXISTS games_titulo_normalized_key ON public.games (public.normalize_title(titulo));
+CREATE INDEX IF NOT EXISTS games_titulo_trgm_idx ON public.games USING gin (public.normalize_title(titulo) gin_trgm_ops);
+
+-- Historial de revisiones de cada juego
+CREATE TABLE IF NOT EXISTS public.game_revisions (
+    id SERIAL PRIMARY KEY,
+    game_id       INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    revision      INTEGER NOT NULL,
+    titulo        VARCHAR(150) NOT NULL,
+    descripcion   VARCHAR(255) NOT NULL,
+    categoria     VARCHAR(50) NOT NULL,
+    fecha         DATE NOT NULL,
+    estado        VARCHAR(20) CHECK (estado IN ('none', 'deseado', 'comprado')) NOT NULL,
+    imagen        VARCHAR(50) NOT NULL,
+    reverted_from INTEGER,
+    created_at    TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, revision)
+);
+
+-- Datos iniciales, solo en una base vacía
+INSERT INTO public.games (titulo, descripcion, categoria, fecha, estado, imagen)
+SELECT * FROM (VALUES
+    ('FIFA25','Simulador de Fútbol','Deporte','2024-09-10'::date,'comprado','img/fifa25.png'),
+    ('Call of Duty','Juego de disparos','Accion','2021-06-21'::date,'none','img/cod.png'),
+    ('FIFA26','Simulador de Fútbol','Deporte','2025-09-15'::date,'deseado','img/fifa26.png'),
+    ('Battlefield 5','Juego de disparos','Accion','2023-06-21'::date,'none','img/btf5.png')
+) AS v
+WHERE NOT EXISTS (SELECT 1 FROM public.games);
+
+-- Revisión inicial de los juegos que todavía no tienen historial
+INSERT INTO public.game_revisions (game_id, revision, titulo, descripcion, categoria, fecha, estado, imagen)
+SELECT id, 1, titulo, descripcion, categoria, fecha, estado, imagen FROM public.games g
+WHERE NOT EXISTS (SELECT 1 FROM public.game_revisions r WHERE r.game_id = g.id);
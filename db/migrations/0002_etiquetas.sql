@@ -0,0 +1,11 @@
+-- Etiquetas de los juegos
+CREATE TABLE IF NOT EXISTS public.tags (
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(30) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS public.game_tags (
+    game_id INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    tag_id  INTEGER NOT NULL REFERENCES public.tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (game_id, tag_id)
+);
@@ -0,0 +1,14 @@
+-- Compras de los juegos; el precio se guarda en centavos de currency (ISO 4217)
+CREATE TABLE IF NOT EXISTS public.purchases (
+    id           SERIAL PRIMARY KEY,
+    game_id      INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    price_cents  INTEGER NOT NULL CHECK (price_cents >= 0),
+    currency     CHAR(3) NOT NULL,
+    store        VARCHAR(50) NOT NULL,
+    purchased_on DATE NOT NULL,
+    format       VARCHAR(10) CHECK (format IN ('physical', 'digital')) NOT NULL,
+    notes        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS purchases_game_id_idx ON public.purchases (game_id);
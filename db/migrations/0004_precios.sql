@@ -0,0 +1,30 @@
+-- Precio objetivo de un juego deseado; uno por juego
+CREATE TABLE IF NOT EXISTS public.price_watches (
+    game_id      INTEGER PRIMARY KEY REFERENCES public.games(id) ON DELETE CASCADE,
+    target_cents INTEGER NOT NULL CHECK (target_cents >= 0),
+    currency     CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Precios observados, cargados a mano o por una fuente de precios
+CREATE TABLE IF NOT EXISTS public.price_observations (
+    id          SERIAL PRIMARY KEY,
+    game_id     INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+    currency    CHAR(3) NOT NULL,
+    source      VARCHAR(30) NOT NULL,
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS price_observations_game_id_idx ON public.price_observations (game_id, observed_at);
+
+-- Avisos de la aplicación; el texto se arma al mostrarlos según kind
+CREATE TABLE IF NOT EXISTS public.notifications (
+    id          SERIAL PRIMARY KEY,
+    game_id     INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    kind        VARCHAR(30) NOT NULL,
+    price_cents INTEGER NOT NULL,
+    currency    CHAR(3) NOT NULL,
+    read_at     TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
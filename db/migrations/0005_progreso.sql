@@ -0,0 +1,21 @@
+-- Estado de juego (aparte de estado, que es de la colección); un juego sin
+-- fila está en el backlog. completed_on es la fecha en que se terminó.
+CREATE TABLE IF NOT EXISTS public.game_progress (
+    game_id      INTEGER PRIMARY KEY REFERENCES public.games(id) ON DELETE CASCADE,
+    play_status  VARCHAR(20) CHECK (play_status IN ('backlog', 'playing', 'finished', 'abandoned', 'completed')) NOT NULL,
+    completed_on DATE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Sesiones de juego; ended_at queda NULL mientras la sesión está en curso
+CREATE TABLE IF NOT EXISTS public.play_sessions (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at   TIMESTAMP WITH TIME ZONE CHECK (ended_at >= started_at),
+    notes      VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS play_sessions_game_id_idx ON public.play_sessions (game_id, started_at);
+-- Un juego tiene a lo sumo una sesión en curso
+CREATE UNIQUE INDEX IF NOT EXISTS play_sessions_open_key ON public.play_sessions (game_id) WHERE ended_at IS NULL;
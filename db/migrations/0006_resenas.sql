@@ -0,0 +1,13 @@
+-- Puntajes y reseñas. No hay cuentas de usuario: el autor es el nombre que
+-- se carga con la reseña, y cada autor tiene una sola por juego. body es
+-- Markdown y se sanitiza al mostrarlo.
+CREATE TABLE IF NOT EXISTS public.reviews (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    reviewer   VARCHAR(50) NOT NULL,
+    rating     INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 10),
+    body       VARCHAR(5000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, reviewer)
+);
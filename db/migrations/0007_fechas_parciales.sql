@@ -0,0 +1,4 @@
+-- Qué tan precisa es la fecha de salida; fecha guarda el último día del
+-- período (o 9999-12-31 si está por anunciar) para que ordene bien
+ALTER TABLE public.games ADD COLUMN IF NOT EXISTS fecha_precision VARCHAR(10) NOT NULL DEFAULT 'day' CHECK (fecha_precision IN ('day', 'month', 'quarter', 'year', 'tba'));
+ALTER TABLE public.game_revisions ADD COLUMN IF NOT EXISTS fecha_precision VARCHAR(10) NOT NULL DEFAULT 'day' CHECK (fecha_precision IN ('day', 'month', 'quarter', 'year', 'tba'));
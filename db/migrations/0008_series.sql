@@ -0,0 +1,48 @@
+-- Series o franquicias (FIFA, Call of Duty, ...)
+CREATE TABLE IF NOT EXISTS public.series (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Entregas de cada serie; un juego pertenece a lo sumo a una. position ordena
+-- las entregas dentro de la serie y puede tener huecos.
+CREATE TABLE IF NOT EXISTS public.series_entries (
+    game_id   INTEGER PRIMARY KEY REFERENCES public.games(id) ON DELETE CASCADE,
+    series_id INTEGER NOT NULL REFERENCES public.series(id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL CHECK (position > 0)
+);
+
+CREATE INDEX IF NOT EXISTS series_entries_series_id_idx ON public.series_entries (series_id, position);
+
+-- Ediciones de un juego (estándar, deluxe, GOTY, ...). owned marca las que
+-- se tienen: alcanza con una para contar la entrega como propia en la serie.
+CREATE TABLE IF NOT EXISTS public.game_editions (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES public.games(id) ON DELETE CASCADE,
+    edition    VARCHAR(20) CHECK (edition IN ('standard', 'deluxe', 'goty', 'ultimate', 'collector')) NOT NULL,
+    owned      BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, edition)
+);
+
+-- Series de los datos iniciales, solo si todavía no hay ninguna y para los
+-- juegos de ejemplo que sigan cargados
+INSERT INTO public.series (name)
+SELECT DISTINCT e.serie
+FROM (VALUES ('FIFA25', 'FIFA'), ('FIFA26', 'FIFA'), ('Call of Duty', 'Call of Duty'), ('Battlefield 5', 'Battlefield')) AS e (titulo, serie)
+JOIN public.games g ON g.titulo = e.titulo
+WHERE NOT EXISTS (SELECT 1 FROM public.series);
+
+INSERT INTO public.series_entries (game_id, series_id, position)
+SELECT g.id, s.id, e.position
+FROM (VALUES ('FIFA25', 'FIFA', 1), ('FIFA26', 'FIFA', 2), ('Call of Duty', 'Call of Duty', 1), ('Battlefield 5', 'Battlefield', 5)) AS e (titulo, serie, position)
+JOIN public.games g ON g.titulo = e.titulo
+JOIN public.series s ON s.name = e.serie
+WHERE NOT EXISTS (SELECT 1 FROM public.series_entries)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.game_editions (game_id, edition, owned)
+SELECT id, 'standard', true FROM public.games
+WHERE titulo = 'FIFA25' AND NOT EXISTS (SELECT 1 FROM public.game_editions)
+ON CONFLICT DO NOTHING;
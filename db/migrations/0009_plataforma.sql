@@ -0,0 +1,8 @@
+-- Plataforma del juego (PC, PS5, Switch, ...); vacía si no se indica. El
+-- título ya no es único en toda la colección sino por plataforma, para poder
+-- tener el mismo juego en dos consolas.
+ALTER TABLE public.games ADD COLUMN plataforma VARCHAR(30) NOT NULL DEFAULT '';
+ALTER TABLE public.game_revisions ADD COLUMN plataforma VARCHAR(30) NOT NULL DEFAULT '';
+
+DROP INDEX public.games_titulo_normalized_key;
+CREATE UNIQUE INDEX games_plataforma_titulo_key ON public.games (plataforma, public.normalize_title(titulo));
@@ -1,27 +1,39 @@
 -- name: GetGame :one
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
 FROM games
 WHERE id = $1;
 
 -- name: ListGames :many
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
-FROM games
-ORDER BY titulo;
+SELECT g.id, g.titulo, g.descripcion, g.categoria, g.plataforma, to_char(g.fecha, 'YYYY-MM-DD') AS fecha, g.fecha_precision, g.estado, g.imagen, g.created_at,
+       COALESCE(AVG(r.rating), 0)::float8 AS avg_rating, COUNT(r.id) AS review_count,
+       COALESCE(s.id, 0)::int AS series_id, COALESCE(s.name, '') AS series_name, COALESCE(e.position, 0)::int AS series_position
+FROM games g
+LEFT JOIN reviews r ON r.game_id = g.id
+LEFT JOIN series_entries e ON e.game_id = g.id
+LEFT JOIN series s ON s.id = e.series_id
+GROUP BY g.id, e.game_id, s.id
+ORDER BY g.titulo;
 
 -- name: ListWantedGames :many
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
 FROM games
 WHERE estado = 'deseado'
 ORDER BY titulo;
 
+-- name: ListReleasesBetween :many
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
+FROM games
+WHERE fecha BETWEEN @from_date::date AND @to_date::date AND fecha_precision = 'day'
+ORDER BY games.fecha, titulo;
+
 -- name: CreateGame :one
-INSERT INTO games (titulo, descripcion, categoria, fecha, estado, imagen)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at;
+INSERT INTO games (titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at;
 
 -- name: UpdateGame :one
 UPDATE games
-SET titulo = $2, descripcion = $3, categoria = $4, fecha = $5, estado = $6, imagen = $7
+SET titulo = $2, descripcion = $3, categoria = $4, plataforma = $5, fecha = $6, fecha_precision = $7, estado = $8, imagen = $9
 WHERE id = $1
 RETURNING *;
 
@@ -31,7 +43,281 @@ SET estado = $2
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateGameCategory :one
+UPDATE games
+SET categoria = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteGame :one
 DELETE FROM games
 WHERE id = $1
-RETURNING *;
\ No newline at end of file
+RETURNING *;
+
+-- name: CreateGameRevision :one
+INSERT INTO game_revisions (game_id, revision, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, reverted_from)
+VALUES ($1, (SELECT COALESCE(MAX(revision), 0) + 1 FROM game_revisions WHERE game_id = $1), $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: ListGameRevisions :many
+SELECT id, game_id, revision, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, reverted_from, created_at
+FROM game_revisions
+WHERE game_id = $1
+ORDER BY revision DESC;
+
+-- name: GetGameRevision :one
+SELECT * FROM game_revisions
+WHERE game_id = $1 AND revision = $2;
+
+
+-- name: ListSimilarGames :many
+SELECT id, titulo, plataforma, similarity(normalize_title(titulo), normalize_title(sqlc.arg(titulo)))::float8 AS score
+FROM games
+WHERE normalize_title(titulo) % normalize_title(sqlc.arg(titulo))
+ORDER BY score DESC
+LIMIT 5;
+
+-- name: CountGamesByEstado :many
+SELECT estado, COUNT(*) AS total
+FROM games
+GROUP BY estado
+ORDER BY estado;
+
+-- name: CountGamesByCategoria :many
+SELECT categoria, COUNT(*) AS total
+FROM games
+GROUP BY categoria
+ORDER BY total DESC, categoria;
+
+-- name: CountGamesByReleaseYear :many
+SELECT EXTRACT(YEAR FROM fecha)::int AS year, COUNT(*) AS total
+FROM games
+WHERE fecha_precision <> 'tba'
+GROUP BY year
+ORDER BY year;
+
+-- name: CountGamesAddedByMonth :many
+SELECT to_char(created_at, 'YYYY-MM') AS month, COUNT(*) AS total
+FROM games
+WHERE created_at IS NOT NULL
+GROUP BY month
+ORDER BY month;
+
+-- name: GetSchemaVersion :one
+SELECT COALESCE(MAX(version), 0)::int AS version
+FROM schema_version;
+
+-- name: UpsertTag :one
+INSERT INTO tags (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: AddGameTag :exec
+INSERT INTO game_tags (game_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: ListGameTags :many
+SELECT t.name
+FROM tags t
+JOIN game_tags gt ON gt.tag_id = t.id
+WHERE gt.game_id = $1
+ORDER BY t.name;
+
+-- name: CreatePurchase :one
+INSERT INTO purchases (game_id, price_cents, currency, store, purchased_on, format, notes)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListGamePurchases :many
+SELECT id, game_id, price_cents, currency, store, to_char(purchased_on, 'YYYY-MM-DD') AS purchased_on, format, notes, created_at
+FROM purchases
+WHERE game_id = $1
+ORDER BY purchased_on DESC, id DESC;
+
+-- name: SpendByMonth :many
+SELECT to_char(purchased_on, 'YYYY-MM') AS month, currency, SUM(price_cents)::bigint AS total_cents
+FROM purchases
+GROUP BY month, currency
+ORDER BY month, currency;
+
+-- name: SpendByCategoria :many
+SELECT g.categoria, p.currency, SUM(p.price_cents)::bigint AS total_cents
+FROM purchases p
+JOIN games g ON g.id = p.game_id
+GROUP BY g.categoria, p.currency
+ORDER BY total_cents DESC, g.categoria, p.currency;
+
+-- name: UpsertPriceWatch :one
+INSERT INTO price_watches (game_id, target_cents, currency)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET target_cents = EXCLUDED.target_cents, currency = EXCLUDED.currency
+RETURNING *;
+
+-- name: GetPriceWatch :one
+SELECT * FROM price_watches
+WHERE game_id = $1;
+
+-- name: DeletePriceWatch :exec
+DELETE FROM price_watches
+WHERE game_id = $1;
+
+-- name: ListPriceWatches :many
+SELECT w.game_id, g.titulo, w.target_cents, w.currency
+FROM price_watches w
+JOIN games g ON g.id = w.game_id
+WHERE g.estado = 'deseado'
+ORDER BY g.titulo;
+
+-- name: CreatePriceObservation :one
+INSERT INTO price_observations (game_id, price_cents, currency, source)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetLatestPriceObservation :one
+SELECT * FROM price_observations
+WHERE game_id = $1
+ORDER BY observed_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListPriceObservations :many
+SELECT * FROM price_observations
+WHERE game_id = $1
+ORDER BY observed_at, id;
+
+-- name: CreateNotification :one
+INSERT INTO notifications (game_id, kind, price_cents, currency)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListNotifications :many
+SELECT n.id, n.game_id, g.titulo, n.kind, n.price_cents, n.currency, n.read_at, n.created_at
+FROM notifications n
+JOIN games g ON g.id = n.game_id
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT 50;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE read_at IS NULL;
+
+-- name: MarkNotificationsRead :exec
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE read_at IS NULL;
+
+-- name: UpsertGameProgress :one
+INSERT INTO game_progress (game_id, play_status, completed_on)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET play_status = EXCLUDED.play_status, completed_on = EXCLUDED.completed_on, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetGameProgress :one
+SELECT * FROM game_progress
+WHERE game_id = $1;
+
+-- name: CreatePlaySession :one
+INSERT INTO play_sessions (game_id, started_at, ended_at, notes)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: EndPlaySession :one
+UPDATE play_sessions
+SET ended_at = $2
+WHERE game_id = $1 AND ended_at IS NULL
+RETURNING *;
+
+-- name: ListPlaySessions :many
+SELECT * FROM play_sessions
+WHERE game_id = $1
+ORDER BY started_at DESC, id DESC;
+
+-- name: ListPlayingGames :many
+SELECT g.id, g.titulo, g.imagen,
+       COALESCE(SUM(EXTRACT(EPOCH FROM s.ended_at - s.started_at)), 0)::bigint AS played_seconds,
+       COUNT(s.id) FILTER (WHERE s.ended_at IS NULL) > 0 AS in_session
+FROM game_progress p
+JOIN games g ON g.id = p.game_id
+LEFT JOIN play_sessions s ON s.game_id = g.id
+WHERE p.play_status = 'playing'
+GROUP BY g.id
+ORDER BY MAX(s.started_at) DESC NULLS LAST, g.titulo;
+
+-- name: UpsertReview :one
+INSERT INTO reviews (game_id, reviewer, rating, body)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (game_id, reviewer) DO UPDATE SET rating = EXCLUDED.rating, body = EXCLUDED.body, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListGameReviews :many
+SELECT * FROM reviews
+WHERE game_id = $1
+ORDER BY updated_at DESC, id DESC;
+
+-- name: DeleteReview :exec
+DELETE FROM reviews
+WHERE id = $1 AND game_id = $2;
+
+-- name: CreateSeries :one
+INSERT INTO series (name)
+VALUES ($1)
+RETURNING *;
+
+-- name: GetSeries :one
+SELECT * FROM series
+WHERE id = $1;
+
+-- name: DeleteSeries :exec
+DELETE FROM series
+WHERE id = $1;
+
+-- name: ListSeries :many
+-- Una entrega cuenta como propia si el juego está comprado o se tiene
+-- alguna de sus ediciones.
+SELECT s.id, s.name, COUNT(g.id) AS entries,
+       COUNT(g.id) FILTER (WHERE g.estado = 'comprado' OR EXISTS (SELECT 1 FROM game_editions ed WHERE ed.game_id = g.id AND ed.owned)) AS owned
+FROM series s
+LEFT JOIN series_entries e ON e.series_id = s.id
+LEFT JOIN games g ON g.id = e.game_id
+GROUP BY s.id
+ORDER BY s.name;
+
+-- name: ListSeriesEntries :many
+SELECT g.id, g.titulo, to_char(g.fecha, 'YYYY-MM-DD') AS fecha, g.fecha_precision, g.estado, g.imagen, e.position,
+       (g.estado = 'comprado' OR EXISTS (SELECT 1 FROM game_editions ed WHERE ed.game_id = g.id AND ed.owned))::bool AS owned
+FROM series_entries e
+JOIN games g ON g.id = e.game_id
+WHERE e.series_id = $1
+ORDER BY e.position, g.titulo;
+
+-- name: SetSeriesEntry :one
+INSERT INTO series_entries (game_id, series_id, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET series_id = EXCLUDED.series_id, position = EXCLUDED.position
+RETURNING *;
+
+-- name: GetGameSeries :one
+SELECT s.id, s.name, e.position
+FROM series_entries e
+JOIN series s ON s.id = e.series_id
+WHERE e.game_id = $1;
+
+-- name: DeleteSeriesEntry :exec
+DELETE FROM series_entries
+WHERE game_id = $1;
+
+-- name: UpsertGameEdition :one
+INSERT INTO game_editions (game_id, edition, owned)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id, edition) DO UPDATE SET owned = EXCLUDED.owned
+RETURNING *;
+
+-- name: ListGameEditions :many
+SELECT * FROM game_editions
+WHERE game_id = $1
+ORDER BY array_position(ARRAY['standard', 'deluxe', 'goty', 'ultimate', 'collector'], edition::text);
+
+-- name: DeleteGameEdition :exec
+DELETE FROM game_editions
+WHERE game_id = $1 AND edition = $2;
@@ -1,10 +1,176 @@
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Título normalizado (sin mayúsculas, acentos ni espacios repetidos) para detectar duplicados
+CREATE FUNCTION normalize_title(text) RETURNS text AS $$
+    SELECT regexp_replace(lower(public.unaccent('public.unaccent'::regdictionary, $1)), '\s+', ' ', 'g')
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
 CREATE TABLE games (
     id SERIAL PRIMARY KEY,
     titulo       VARCHAR(150) NOT NULL,
     descripcion  VARCHAR(255) NOT NULL,
     categoria    VARCHAR(50) NOT NULL,
+    -- Plataforma (PC, PS5, Switch, ...); vacía si no se indica
+    plataforma   VARCHAR(30) NOT NULL DEFAULT '',
     fecha        DATE NOT NULL,
+    -- Qué tan precisa es la fecha; fecha guarda el último día del período
+    -- (o 9999-12-31 si está por anunciar) para que ordene bien
+    fecha_precision VARCHAR(10) NOT NULL DEFAULT 'day' CHECK (fecha_precision IN ('day', 'month', 'quarter', 'year', 'tba')),
     estado       VARCHAR(20) CHECK (estado IN ('none', 'deseado', 'comprado')) NOT NULL,
     imagen      VARCHAR(50) NOT NULL,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
-);
\ No newline at end of file
+);
+
+-- Un mismo título (ignorando mayúsculas y acentos) no puede cargarse dos veces
+-- en la misma plataforma
+CREATE UNIQUE INDEX games_plataforma_titulo_key ON games (plataforma, normalize_title(titulo));
+CREATE INDEX games_titulo_trgm_idx ON games USING gin (normalize_title(titulo) gin_trgm_ops);
+
+CREATE TABLE game_revisions (
+    id SERIAL PRIMARY KEY,
+    game_id       INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    revision      INTEGER NOT NULL,
+    titulo        VARCHAR(150) NOT NULL,
+    descripcion   VARCHAR(255) NOT NULL,
+    categoria     VARCHAR(50) NOT NULL,
+    plataforma    VARCHAR(30) NOT NULL DEFAULT '',
+    fecha         DATE NOT NULL,
+    fecha_precision VARCHAR(10) NOT NULL DEFAULT 'day' CHECK (fecha_precision IN ('day', 'month', 'quarter', 'year', 'tba')),
+    estado        VARCHAR(20) CHECK (estado IN ('none', 'deseado', 'comprado')) NOT NULL,
+    imagen        VARCHAR(50) NOT NULL,
+    reverted_from INTEGER,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, revision)
+);
+
+CREATE TABLE tags (
+    id   SERIAL PRIMARY KEY,
+    name VARCHAR(30) NOT NULL UNIQUE
+);
+
+CREATE TABLE game_tags (
+    game_id INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    tag_id  INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (game_id, tag_id)
+);
+
+-- Compras de los juegos; el precio se guarda en centavos de currency (ISO 4217)
+CREATE TABLE purchases (
+    id           SERIAL PRIMARY KEY,
+    game_id      INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    price_cents  INTEGER NOT NULL CHECK (price_cents >= 0),
+    currency     CHAR(3) NOT NULL,
+    store        VARCHAR(50) NOT NULL,
+    purchased_on DATE NOT NULL,
+    format       VARCHAR(10) CHECK (format IN ('physical', 'digital')) NOT NULL,
+    notes        VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX purchases_game_id_idx ON purchases (game_id);
+
+-- Precio objetivo de un juego deseado; uno por juego
+CREATE TABLE price_watches (
+    game_id      INTEGER PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    target_cents INTEGER NOT NULL CHECK (target_cents >= 0),
+    currency     CHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Precios observados, cargados a mano o por una fuente de precios
+CREATE TABLE price_observations (
+    id          SERIAL PRIMARY KEY,
+    game_id     INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    price_cents INTEGER NOT NULL CHECK (price_cents >= 0),
+    currency    CHAR(3) NOT NULL,
+    source      VARCHAR(30) NOT NULL,
+    observed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX price_observations_game_id_idx ON price_observations (game_id, observed_at);
+
+-- Avisos de la aplicación; el texto se arma al mostrarlos según kind
+CREATE TABLE notifications (
+    id          SERIAL PRIMARY KEY,
+    game_id     INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    kind        VARCHAR(30) NOT NULL,
+    price_cents INTEGER NOT NULL,
+    currency    CHAR(3) NOT NULL,
+    read_at     TIMESTAMP WITH TIME ZONE,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Estado de juego (aparte de estado, que es de la colección); un juego sin
+-- fila está en el backlog. completed_on es la fecha en que se terminó.
+CREATE TABLE game_progress (
+    game_id      INTEGER PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    play_status  VARCHAR(20) CHECK (play_status IN ('backlog', 'playing', 'finished', 'abandoned', 'completed')) NOT NULL,
+    completed_on DATE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Sesiones de juego; ended_at queda NULL mientras la sesión está en curso
+CREATE TABLE play_sessions (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at   TIMESTAMP WITH TIME ZONE CHECK (ended_at >= started_at),
+    notes      VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX play_sessions_game_id_idx ON play_sessions (game_id, started_at);
+-- Un juego tiene a lo sumo una sesión en curso
+CREATE UNIQUE INDEX play_sessions_open_key ON play_sessions (game_id) WHERE ended_at IS NULL;
+
+-- Puntajes y reseñas. No hay cuentas de usuario: el autor es el nombre que
+-- se carga con la reseña, y cada autor tiene una sola por juego. body es
+-- Markdown y se sanitiza al mostrarlo.
+CREATE TABLE reviews (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    reviewer   VARCHAR(50) NOT NULL,
+    rating     INTEGER NOT NULL CHECK (rating BETWEEN 1 AND 10),
+    body       VARCHAR(5000) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, reviewer)
+);
+
+-- Series o franquicias (FIFA, Call of Duty, ...)
+CREATE TABLE series (
+    id         SERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Entregas de cada serie; un juego pertenece a lo sumo a una. position ordena
+-- las entregas dentro de la serie y puede tener huecos.
+CREATE TABLE series_entries (
+    game_id   INTEGER PRIMARY KEY REFERENCES games(id) ON DELETE CASCADE,
+    series_id INTEGER NOT NULL REFERENCES series(id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL CHECK (position > 0)
+);
+
+CREATE INDEX series_entries_series_id_idx ON series_entries (series_id, position);
+
+-- Ediciones de un juego (estándar, deluxe, GOTY, ...). owned marca las que
+-- se tienen: alcanza con una para contar la entrega como propia en la serie.
+CREATE TABLE game_editions (
+    id         SERIAL PRIMARY KEY,
+    game_id    INTEGER NOT NULL REFERENCES games(id) ON DELETE CASCADE,
+    edition    VARCHAR(20) CHECK (edition IN ('standard', 'deluxe', 'goty', 'ultimate', 'collector')) NOT NULL,
+    owned      BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (game_id, edition)
+);
+
+-- Versión del esquema: /readyz la compara con db.SchemaVersion. Este archivo
+-- es la referencia de sqlc; cada cambio de esquema va también como migración
+-- en db/migrations y agrega aquí la fila con la versión siguiente.
+CREATE TABLE schema_version (
+    version    INTEGER PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO schema_version (version) VALUES (1), (2), (3), (4), (5), (6), (7), (8), (9);
@@ -10,12 +10,128 @@ import (
 )
 
 type Game struct {
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          time.Time    `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+}
+
+type GameEdition struct {
+	ID        int32        `json:"id"`
+	GameID    int32        `json:"game_id"`
+	Edition   string       `json:"edition"`
+	Owned     bool         `json:"owned"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type GameProgress struct {
+	GameID      int32        `json:"game_id"`
+	PlayStatus  string       `json:"play_status"`
+	CompletedOn sql.NullTime `json:"completed_on"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+}
+
+type GameRevision struct {
+	ID             int32         `json:"id"`
+	GameID         int32         `json:"game_id"`
+	Revision       int32         `json:"revision"`
+	Titulo         string        `json:"titulo"`
+	Descripcion    string        `json:"descripcion"`
+	Categoria      string        `json:"categoria"`
+	Plataforma     string        `json:"plataforma"`
+	Fecha          time.Time     `json:"fecha"`
+	FechaPrecision string        `json:"fecha_precision"`
+	Estado         string        `json:"estado"`
+	Imagen         string        `json:"imagen"`
+	RevertedFrom   sql.NullInt32 `json:"reverted_from"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+}
+
+type GameTag struct {
+	GameID int32 `json:"game_id"`
+	TagID  int32 `json:"tag_id"`
+}
+
+type Notification struct {
+	ID         int32        `json:"id"`
+	GameID     int32        `json:"game_id"`
+	Kind       string       `json:"kind"`
+	PriceCents int32        `json:"price_cents"`
+	Currency   string       `json:"currency"`
+	ReadAt     sql.NullTime `json:"read_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type PlaySession struct {
+	ID        int32        `json:"id"`
+	GameID    int32        `json:"game_id"`
+	StartedAt time.Time    `json:"started_at"`
+	EndedAt   sql.NullTime `json:"ended_at"`
+	Notes     string       `json:"notes"`
+}
+
+type PriceObservation struct {
+	ID         int32     `json:"id"`
+	GameID     int32     `json:"game_id"`
+	PriceCents int32     `json:"price_cents"`
+	Currency   string    `json:"currency"`
+	Source     string    `json:"source"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+type PriceWatch struct {
+	GameID      int32        `json:"game_id"`
+	TargetCents int32        `json:"target_cents"`
+	Currency    string       `json:"currency"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+type Purchase struct {
 	ID          int32        `json:"id"`
-	Titulo      string       `json:"titulo"`
-	Descripcion string       `json:"descripcion"`
-	Categoria   string       `json:"categoria"`
-	Fecha       time.Time    `json:"fecha"`
-	Estado      string       `json:"estado"`
-	Imagen      string       `json:"imagen"`
+	GameID      int32        `json:"game_id"`
+	PriceCents  int32        `json:"price_cents"`
+	Currency    string       `json:"currency"`
+	Store       string       `json:"store"`
+	PurchasedOn time.Time    `json:"purchased_on"`
+	Format      string       `json:"format"`
+	Notes       string       `json:"notes"`
 	CreatedAt   sql.NullTime `json:"created_at"`
 }
+
+type Review struct {
+	ID        int32        `json:"id"`
+	GameID    int32        `json:"game_id"`
+	Reviewer  string       `json:"reviewer"`
+	Rating    int32        `json:"rating"`
+	Body      string       `json:"body"`
+	CreatedAt sql.NullTime `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+}
+
+type SchemaVersion struct {
+	Version   int32        `json:"version"`
+	AppliedAt sql.NullTime `json:"applied_at"`
+}
+
+type Series struct {
+	ID        int32        `json:"id"`
+	Name      string       `json:"name"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type SeriesEntry struct {
+	GameID   int32 `json:"game_id"`
+	SeriesID int32 `json:"series_id"`
+	Position int32 `json:"position"`
+}
+
+type Tag struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+}
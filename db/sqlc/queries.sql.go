@@ -11,30 +11,204 @@ import (
 	"time"
 )
 
+const addGameTag = `-- name: AddGameTag :exec
+INSERT INTO game_tags (game_id, tag_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddGameTagParams struct {
+	GameID int32 `json:"game_id"`
+	TagID  int32 `json:"tag_id"`
+}
+
+func (q *Queries) AddGameTag(ctx context.Context, arg AddGameTagParams) error {
+	_, err := q.db.ExecContext(ctx, addGameTag, arg.GameID, arg.TagID)
+	return err
+}
+
+const countGamesAddedByMonth = `-- name: CountGamesAddedByMonth :many
+SELECT to_char(created_at, 'YYYY-MM') AS month, COUNT(*) AS total
+FROM games
+WHERE created_at IS NOT NULL
+GROUP BY month
+ORDER BY month
+`
+
+type CountGamesAddedByMonthRow struct {
+	Month string `json:"month"`
+	Total int64  `json:"total"`
+}
+
+func (q *Queries) CountGamesAddedByMonth(ctx context.Context) ([]CountGamesAddedByMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGamesAddedByMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGamesAddedByMonthRow
+	for rows.Next() {
+		var i CountGamesAddedByMonthRow
+		if err := rows.Scan(&i.Month, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countGamesByCategoria = `-- name: CountGamesByCategoria :many
+SELECT categoria, COUNT(*) AS total
+FROM games
+GROUP BY categoria
+ORDER BY total DESC, categoria
+`
+
+type CountGamesByCategoriaRow struct {
+	Categoria string `json:"categoria"`
+	Total     int64  `json:"total"`
+}
+
+func (q *Queries) CountGamesByCategoria(ctx context.Context) ([]CountGamesByCategoriaRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGamesByCategoria)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGamesByCategoriaRow
+	for rows.Next() {
+		var i CountGamesByCategoriaRow
+		if err := rows.Scan(&i.Categoria, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countGamesByEstado = `-- name: CountGamesByEstado :many
+SELECT estado, COUNT(*) AS total
+FROM games
+GROUP BY estado
+ORDER BY estado
+`
+
+type CountGamesByEstadoRow struct {
+	Estado string `json:"estado"`
+	Total  int64  `json:"total"`
+}
+
+func (q *Queries) CountGamesByEstado(ctx context.Context) ([]CountGamesByEstadoRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGamesByEstado)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGamesByEstadoRow
+	for rows.Next() {
+		var i CountGamesByEstadoRow
+		if err := rows.Scan(&i.Estado, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countGamesByReleaseYear = `-- name: CountGamesByReleaseYear :many
+SELECT EXTRACT(YEAR FROM fecha)::int AS year, COUNT(*) AS total
+FROM games
+WHERE fecha_precision <> 'tba'
+GROUP BY year
+ORDER BY year
+`
+
+type CountGamesByReleaseYearRow struct {
+	Year  int32 `json:"year"`
+	Total int64 `json:"total"`
+}
+
+func (q *Queries) CountGamesByReleaseYear(ctx context.Context) ([]CountGamesByReleaseYearRow, error) {
+	rows, err := q.db.QueryContext(ctx, countGamesByReleaseYear)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountGamesByReleaseYearRow
+	for rows.Next() {
+		var i CountGamesByReleaseYearRow
+		if err := rows.Scan(&i.Year, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+SELECT COUNT(*) FROM notifications
+WHERE read_at IS NULL
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUnreadNotifications)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createGame = `-- name: CreateGame :one
-INSERT INTO games (titulo, descripcion, categoria, fecha, estado, imagen)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
+INSERT INTO games (titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
 `
 
 type CreateGameParams struct {
-	Titulo      string    `json:"titulo"`
-	Descripcion string    `json:"descripcion"`
-	Categoria   string    `json:"categoria"`
-	Fecha       time.Time `json:"fecha"`
-	Estado      string    `json:"estado"`
-	Imagen      string    `json:"imagen"`
+	Titulo         string    `json:"titulo"`
+	Descripcion    string    `json:"descripcion"`
+	Categoria      string    `json:"categoria"`
+	Plataforma     string    `json:"plataforma"`
+	Fecha          time.Time `json:"fecha"`
+	FechaPrecision string    `json:"fecha_precision"`
+	Estado         string    `json:"estado"`
+	Imagen         string    `json:"imagen"`
 }
 
 type CreateGameRow struct {
-	ID          int32        `json:"id"`
-	Titulo      string       `json:"titulo"`
-	Descripcion string       `json:"descripcion"`
-	Categoria   string       `json:"categoria"`
-	Fecha       string       `json:"fecha"`
-	Estado      string       `json:"estado"`
-	Imagen      string       `json:"imagen"`
-	CreatedAt   sql.NullTime `json:"created_at"`
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
 }
 
 func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (CreateGameRow, error) {
@@ -42,7 +216,9 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (CreateG
 		arg.Titulo,
 		arg.Descripcion,
 		arg.Categoria,
+		arg.Plataforma,
 		arg.Fecha,
+		arg.FechaPrecision,
 		arg.Estado,
 		arg.Imagen,
 	)
@@ -52,18 +228,221 @@ func (q *Queries) CreateGame(ctx context.Context, arg CreateGameParams) (CreateG
 		&i.Titulo,
 		&i.Descripcion,
 		&i.Categoria,
+		&i.Plataforma,
+		&i.Fecha,
+		&i.FechaPrecision,
+		&i.Estado,
+		&i.Imagen,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGameRevision = `-- name: CreateGameRevision :one
+INSERT INTO game_revisions (game_id, revision, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, reverted_from)
+VALUES ($1, (SELECT COALESCE(MAX(revision), 0) + 1 FROM game_revisions WHERE game_id = $1), $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, game_id, revision, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, reverted_from, created_at
+`
+
+type CreateGameRevisionParams struct {
+	GameID         int32         `json:"game_id"`
+	Titulo         string        `json:"titulo"`
+	Descripcion    string        `json:"descripcion"`
+	Categoria      string        `json:"categoria"`
+	Plataforma     string        `json:"plataforma"`
+	Fecha          time.Time     `json:"fecha"`
+	FechaPrecision string        `json:"fecha_precision"`
+	Estado         string        `json:"estado"`
+	Imagen         string        `json:"imagen"`
+	RevertedFrom   sql.NullInt32 `json:"reverted_from"`
+}
+
+func (q *Queries) CreateGameRevision(ctx context.Context, arg CreateGameRevisionParams) (GameRevision, error) {
+	row := q.db.QueryRowContext(ctx, createGameRevision,
+		arg.GameID,
+		arg.Titulo,
+		arg.Descripcion,
+		arg.Categoria,
+		arg.Plataforma,
+		arg.Fecha,
+		arg.FechaPrecision,
+		arg.Estado,
+		arg.Imagen,
+		arg.RevertedFrom,
+	)
+	var i GameRevision
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.Revision,
+		&i.Titulo,
+		&i.Descripcion,
+		&i.Categoria,
+		&i.Plataforma,
 		&i.Fecha,
+		&i.FechaPrecision,
 		&i.Estado,
 		&i.Imagen,
+		&i.RevertedFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createNotification = `-- name: CreateNotification :one
+INSERT INTO notifications (game_id, kind, price_cents, currency)
+VALUES ($1, $2, $3, $4)
+RETURNING id, game_id, kind, price_cents, currency, read_at, created_at
+`
+
+type CreateNotificationParams struct {
+	GameID     int32  `json:"game_id"`
+	Kind       string `json:"kind"`
+	PriceCents int32  `json:"price_cents"`
+	Currency   string `json:"currency"`
+}
+
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
+	row := q.db.QueryRowContext(ctx, createNotification,
+		arg.GameID,
+		arg.Kind,
+		arg.PriceCents,
+		arg.Currency,
+	)
+	var i Notification
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.Kind,
+		&i.PriceCents,
+		&i.Currency,
+		&i.ReadAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPlaySession = `-- name: CreatePlaySession :one
+INSERT INTO play_sessions (game_id, started_at, ended_at, notes)
+VALUES ($1, $2, $3, $4)
+RETURNING id, game_id, started_at, ended_at, notes
+`
+
+type CreatePlaySessionParams struct {
+	GameID    int32        `json:"game_id"`
+	StartedAt time.Time    `json:"started_at"`
+	EndedAt   sql.NullTime `json:"ended_at"`
+	Notes     string       `json:"notes"`
+}
+
+func (q *Queries) CreatePlaySession(ctx context.Context, arg CreatePlaySessionParams) (PlaySession, error) {
+	row := q.db.QueryRowContext(ctx, createPlaySession,
+		arg.GameID,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.Notes,
+	)
+	var i PlaySession
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const createPriceObservation = `-- name: CreatePriceObservation :one
+INSERT INTO price_observations (game_id, price_cents, currency, source)
+VALUES ($1, $2, $3, $4)
+RETURNING id, game_id, price_cents, currency, source, observed_at
+`
+
+type CreatePriceObservationParams struct {
+	GameID     int32  `json:"game_id"`
+	PriceCents int32  `json:"price_cents"`
+	Currency   string `json:"currency"`
+	Source     string `json:"source"`
+}
+
+func (q *Queries) CreatePriceObservation(ctx context.Context, arg CreatePriceObservationParams) (PriceObservation, error) {
+	row := q.db.QueryRowContext(ctx, createPriceObservation,
+		arg.GameID,
+		arg.PriceCents,
+		arg.Currency,
+		arg.Source,
+	)
+	var i PriceObservation
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PriceCents,
+		&i.Currency,
+		&i.Source,
+		&i.ObservedAt,
+	)
+	return i, err
+}
+
+const createPurchase = `-- name: CreatePurchase :one
+INSERT INTO purchases (game_id, price_cents, currency, store, purchased_on, format, notes)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, game_id, price_cents, currency, store, purchased_on, format, notes, created_at
+`
+
+type CreatePurchaseParams struct {
+	GameID      int32     `json:"game_id"`
+	PriceCents  int32     `json:"price_cents"`
+	Currency    string    `json:"currency"`
+	Store       string    `json:"store"`
+	PurchasedOn time.Time `json:"purchased_on"`
+	Format      string    `json:"format"`
+	Notes       string    `json:"notes"`
+}
+
+func (q *Queries) CreatePurchase(ctx context.Context, arg CreatePurchaseParams) (Purchase, error) {
+	row := q.db.QueryRowContext(ctx, createPurchase,
+		arg.GameID,
+		arg.PriceCents,
+		arg.Currency,
+		arg.Store,
+		arg.PurchasedOn,
+		arg.Format,
+		arg.Notes,
+	)
+	var i Purchase
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PriceCents,
+		&i.Currency,
+		&i.Store,
+		&i.PurchasedOn,
+		&i.Format,
+		&i.Notes,
 		&i.CreatedAt,
 	)
 	return i, err
 }
 
+const createSeries = `-- name: CreateSeries :one
+INSERT INTO series (name)
+VALUES ($1)
+RETURNING id, name, created_at
+`
+
+func (q *Queries) CreateSeries(ctx context.Context, name string) (Series, error) {
+	row := q.db.QueryRowContext(ctx, createSeries, name)
+	var i Series
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
 const deleteGame = `-- name: DeleteGame :one
 DELETE FROM games
 WHERE id = $1
-RETURNING id, titulo, descripcion, categoria, fecha, estado, imagen, created_at
+RETURNING id, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, created_at
 `
 
 func (q *Queries) DeleteGame(ctx context.Context, id int32) (Game, error) {
@@ -74,7 +453,131 @@ func (q *Queries) DeleteGame(ctx context.Context, id int32) (Game, error) {
 		&i.Titulo,
 		&i.Descripcion,
 		&i.Categoria,
+		&i.Plataforma,
+		&i.Fecha,
+		&i.FechaPrecision,
+		&i.Estado,
+		&i.Imagen,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteGameEdition = `-- name: DeleteGameEdition :exec
+DELETE FROM game_editions
+WHERE game_id = $1 AND edition = $2
+`
+
+type DeleteGameEditionParams struct {
+	GameID  int32  `json:"game_id"`
+	Edition string `json:"edition"`
+}
+
+func (q *Queries) DeleteGameEdition(ctx context.Context, arg DeleteGameEditionParams) error {
+	_, err := q.db.ExecContext(ctx, deleteGameEdition, arg.GameID, arg.Edition)
+	return err
+}
+
+const deletePriceWatch = `-- name: DeletePriceWatch :exec
+DELETE FROM price_watches
+WHERE game_id = $1
+`
+
+func (q *Queries) DeletePriceWatch(ctx context.Context, gameID int32) error {
+	_, err := q.db.ExecContext(ctx, deletePriceWatch, gameID)
+	return err
+}
+
+const deleteReview = `-- name: DeleteReview :exec
+DELETE FROM reviews
+WHERE id = $1 AND game_id = $2
+`
+
+type DeleteReviewParams struct {
+	ID     int32 `json:"id"`
+	GameID int32 `json:"game_id"`
+}
+
+func (q *Queries) DeleteReview(ctx context.Context, arg DeleteReviewParams) error {
+	_, err := q.db.ExecContext(ctx, deleteReview, arg.ID, arg.GameID)
+	return err
+}
+
+const deleteSeries = `-- name: DeleteSeries :exec
+DELETE FROM series
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSeries(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteSeries, id)
+	return err
+}
+
+const deleteSeriesEntry = `-- name: DeleteSeriesEntry :exec
+DELETE FROM series_entries
+WHERE game_id = $1
+`
+
+func (q *Queries) DeleteSeriesEntry(ctx context.Context, gameID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteSeriesEntry, gameID)
+	return err
+}
+
+const endPlaySession = `-- name: EndPlaySession :one
+UPDATE play_sessions
+SET ended_at = $2
+WHERE game_id = $1 AND ended_at IS NULL
+RETURNING id, game_id, started_at, ended_at, notes
+`
+
+type EndPlaySessionParams struct {
+	GameID  int32        `json:"game_id"`
+	EndedAt sql.NullTime `json:"ended_at"`
+}
+
+func (q *Queries) EndPlaySession(ctx context.Context, arg EndPlaySessionParams) (PlaySession, error) {
+	row := q.db.QueryRowContext(ctx, endPlaySession, arg.GameID, arg.EndedAt)
+	var i PlaySession
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Notes,
+	)
+	return i, err
+}
+
+const getGame = `-- name: GetGame :one
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
+FROM games
+WHERE id = $1
+`
+
+type GetGameRow struct {
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+}
+
+func (q *Queries) GetGame(ctx context.Context, id int32) (GetGameRow, error) {
+	row := q.db.QueryRowContext(ctx, getGame, id)
+	var i GetGameRow
+	err := row.Scan(
+		&i.ID,
+		&i.Titulo,
+		&i.Descripcion,
+		&i.Categoria,
+		&i.Plataforma,
 		&i.Fecha,
+		&i.FechaPrecision,
 		&i.Estado,
 		&i.Imagen,
 		&i.CreatedAt,
@@ -82,71 +585,676 @@ func (q *Queries) DeleteGame(ctx context.Context, id int32) (Game, error) {
 	return i, err
 }
 
-const getGame = `-- name: GetGame :one
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
-FROM games
-WHERE id = $1
+const getGameProgress = `-- name: GetGameProgress :one
+SELECT game_id, play_status, completed_on, updated_at FROM game_progress
+WHERE game_id = $1
+`
+
+func (q *Queries) GetGameProgress(ctx context.Context, gameID int32) (GameProgress, error) {
+	row := q.db.QueryRowContext(ctx, getGameProgress, gameID)
+	var i GameProgress
+	err := row.Scan(
+		&i.GameID,
+		&i.PlayStatus,
+		&i.CompletedOn,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getGameRevision = `-- name: GetGameRevision :one
+SELECT id, game_id, revision, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, reverted_from, created_at FROM game_revisions
+WHERE game_id = $1 AND revision = $2
+`
+
+type GetGameRevisionParams struct {
+	GameID   int32 `json:"game_id"`
+	Revision int32 `json:"revision"`
+}
+
+func (q *Queries) GetGameRevision(ctx context.Context, arg GetGameRevisionParams) (GameRevision, error) {
+	row := q.db.QueryRowContext(ctx, getGameRevision, arg.GameID, arg.Revision)
+	var i GameRevision
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.Revision,
+		&i.Titulo,
+		&i.Descripcion,
+		&i.Categoria,
+		&i.Plataforma,
+		&i.Fecha,
+		&i.FechaPrecision,
+		&i.Estado,
+		&i.Imagen,
+		&i.RevertedFrom,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGameSeries = `-- name: GetGameSeries :one
+SELECT s.id, s.name, e.position
+FROM series_entries e
+JOIN series s ON s.id = e.series_id
+WHERE e.game_id = $1
+`
+
+type GetGameSeriesRow struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Position int32  `json:"position"`
+}
+
+func (q *Queries) GetGameSeries(ctx context.Context, gameID int32) (GetGameSeriesRow, error) {
+	row := q.db.QueryRowContext(ctx, getGameSeries, gameID)
+	var i GetGameSeriesRow
+	err := row.Scan(&i.ID, &i.Name, &i.Position)
+	return i, err
+}
+
+const getLatestPriceObservation = `-- name: GetLatestPriceObservation :one
+SELECT id, game_id, price_cents, currency, source, observed_at FROM price_observations
+WHERE game_id = $1
+ORDER BY observed_at DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestPriceObservation(ctx context.Context, gameID int32) (PriceObservation, error) {
+	row := q.db.QueryRowContext(ctx, getLatestPriceObservation, gameID)
+	var i PriceObservation
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.PriceCents,
+		&i.Currency,
+		&i.Source,
+		&i.ObservedAt,
+	)
+	return i, err
+}
+
+const getPriceWatch = `-- name: GetPriceWatch :one
+SELECT game_id, target_cents, currency, created_at FROM price_watches
+WHERE game_id = $1
+`
+
+func (q *Queries) GetPriceWatch(ctx context.Context, gameID int32) (PriceWatch, error) {
+	row := q.db.QueryRowContext(ctx, getPriceWatch, gameID)
+	var i PriceWatch
+	err := row.Scan(
+		&i.GameID,
+		&i.TargetCents,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getSchemaVersion = `-- name: GetSchemaVersion :one
+SELECT COALESCE(MAX(version), 0)::int AS version
+FROM schema_version
+`
+
+func (q *Queries) GetSchemaVersion(ctx context.Context) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getSchemaVersion)
+	var version int32
+	err := row.Scan(&version)
+	return version, err
+}
+
+const getSeries = `-- name: GetSeries :one
+SELECT id, name, created_at FROM series
+WHERE id = $1
+`
+
+func (q *Queries) GetSeries(ctx context.Context, id int32) (Series, error) {
+	row := q.db.QueryRowContext(ctx, getSeries, id)
+	var i Series
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const listGameEditions = `-- name: ListGameEditions :many
+SELECT id, game_id, edition, owned, created_at FROM game_editions
+WHERE game_id = $1
+ORDER BY array_position(ARRAY['standard', 'deluxe', 'goty', 'ultimate', 'collector'], edition::text)
+`
+
+func (q *Queries) ListGameEditions(ctx context.Context, gameID int32) ([]GameEdition, error) {
+	rows, err := q.db.QueryContext(ctx, listGameEditions, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GameEdition
+	for rows.Next() {
+		var i GameEdition
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Edition,
+			&i.Owned,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGamePurchases = `-- name: ListGamePurchases :many
+SELECT id, game_id, price_cents, currency, store, to_char(purchased_on, 'YYYY-MM-DD') AS purchased_on, format, notes, created_at
+FROM purchases
+WHERE game_id = $1
+ORDER BY purchased_on DESC, id DESC
+`
+
+type ListGamePurchasesRow struct {
+	ID          int32        `json:"id"`
+	GameID      int32        `json:"game_id"`
+	PriceCents  int32        `json:"price_cents"`
+	Currency    string       `json:"currency"`
+	Store       string       `json:"store"`
+	PurchasedOn string       `json:"purchased_on"`
+	Format      string       `json:"format"`
+	Notes       string       `json:"notes"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+}
+
+func (q *Queries) ListGamePurchases(ctx context.Context, gameID int32) ([]ListGamePurchasesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGamePurchases, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGamePurchasesRow
+	for rows.Next() {
+		var i ListGamePurchasesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PriceCents,
+			&i.Currency,
+			&i.Store,
+			&i.PurchasedOn,
+			&i.Format,
+			&i.Notes,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameReviews = `-- name: ListGameReviews :many
+SELECT id, game_id, reviewer, rating, body, created_at, updated_at FROM reviews
+WHERE game_id = $1
+ORDER BY updated_at DESC, id DESC
+`
+
+func (q *Queries) ListGameReviews(ctx context.Context, gameID int32) ([]Review, error) {
+	rows, err := q.db.QueryContext(ctx, listGameReviews, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Review
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Reviewer,
+			&i.Rating,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameRevisions = `-- name: ListGameRevisions :many
+SELECT id, game_id, revision, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, reverted_from, created_at
+FROM game_revisions
+WHERE game_id = $1
+ORDER BY revision DESC
+`
+
+type ListGameRevisionsRow struct {
+	ID             int32         `json:"id"`
+	GameID         int32         `json:"game_id"`
+	Revision       int32         `json:"revision"`
+	Titulo         string        `json:"titulo"`
+	Descripcion    string        `json:"descripcion"`
+	Categoria      string        `json:"categoria"`
+	Plataforma     string        `json:"plataforma"`
+	Fecha          string        `json:"fecha"`
+	FechaPrecision string        `json:"fecha_precision"`
+	Estado         string        `json:"estado"`
+	Imagen         string        `json:"imagen"`
+	RevertedFrom   sql.NullInt32 `json:"reverted_from"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+}
+
+func (q *Queries) ListGameRevisions(ctx context.Context, gameID int32) ([]ListGameRevisionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGameRevisions, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGameRevisionsRow
+	for rows.Next() {
+		var i ListGameRevisionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Revision,
+			&i.Titulo,
+			&i.Descripcion,
+			&i.Categoria,
+			&i.Plataforma,
+			&i.Fecha,
+			&i.FechaPrecision,
+			&i.Estado,
+			&i.Imagen,
+			&i.RevertedFrom,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGameTags = `-- name: ListGameTags :many
+SELECT t.name
+FROM tags t
+JOIN game_tags gt ON gt.tag_id = t.id
+WHERE gt.game_id = $1
+ORDER BY t.name
+`
+
+func (q *Queries) ListGameTags(ctx context.Context, gameID int32) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listGameTags, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGames = `-- name: ListGames :many
+SELECT g.id, g.titulo, g.descripcion, g.categoria, g.plataforma, to_char(g.fecha, 'YYYY-MM-DD') AS fecha, g.fecha_precision, g.estado, g.imagen, g.created_at,
+       COALESCE(AVG(r.rating), 0)::float8 AS avg_rating, COUNT(r.id) AS review_count,
+       COALESCE(s.id, 0)::int AS series_id, COALESCE(s.name, '') AS series_name, COALESCE(e.position, 0)::int AS series_position
+FROM games g
+LEFT JOIN reviews r ON r.game_id = g.id
+LEFT JOIN series_entries e ON e.game_id = g.id
+LEFT JOIN series s ON s.id = e.series_id
+GROUP BY g.id, e.game_id, s.id
+ORDER BY g.titulo
+`
+
+type ListGamesRow struct {
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+	AvgRating      float64      `json:"avg_rating"`
+	ReviewCount    int64        `json:"review_count"`
+	SeriesID       int32        `json:"series_id"`
+	SeriesName     string       `json:"series_name"`
+	SeriesPosition int32        `json:"series_position"`
+}
+
+func (q *Queries) ListGames(ctx context.Context) ([]ListGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listGames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGamesRow
+	for rows.Next() {
+		var i ListGamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Titulo,
+			&i.Descripcion,
+			&i.Categoria,
+			&i.Plataforma,
+			&i.Fecha,
+			&i.FechaPrecision,
+			&i.Estado,
+			&i.Imagen,
+			&i.CreatedAt,
+			&i.AvgRating,
+			&i.ReviewCount,
+			&i.SeriesID,
+			&i.SeriesName,
+			&i.SeriesPosition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotifications = `-- name: ListNotifications :many
+SELECT n.id, n.game_id, g.titulo, n.kind, n.price_cents, n.currency, n.read_at, n.created_at
+FROM notifications n
+JOIN games g ON g.id = n.game_id
+ORDER BY n.created_at DESC, n.id DESC
+LIMIT 50
+`
+
+type ListNotificationsRow struct {
+	ID         int32        `json:"id"`
+	GameID     int32        `json:"game_id"`
+	Titulo     string       `json:"titulo"`
+	Kind       string       `json:"kind"`
+	PriceCents int32        `json:"price_cents"`
+	Currency   string       `json:"currency"`
+	ReadAt     sql.NullTime `json:"read_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+func (q *Queries) ListNotifications(ctx context.Context) ([]ListNotificationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listNotifications)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListNotificationsRow
+	for rows.Next() {
+		var i ListNotificationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.Titulo,
+			&i.Kind,
+			&i.PriceCents,
+			&i.Currency,
+			&i.ReadAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlaySessions = `-- name: ListPlaySessions :many
+SELECT id, game_id, started_at, ended_at, notes FROM play_sessions
+WHERE game_id = $1
+ORDER BY started_at DESC, id DESC
+`
+
+func (q *Queries) ListPlaySessions(ctx context.Context, gameID int32) ([]PlaySession, error) {
+	rows, err := q.db.QueryContext(ctx, listPlaySessions, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PlaySession
+	for rows.Next() {
+		var i PlaySession
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Notes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPlayingGames = `-- name: ListPlayingGames :many
+SELECT g.id, g.titulo, g.imagen,
+       COALESCE(SUM(EXTRACT(EPOCH FROM s.ended_at - s.started_at)), 0)::bigint AS played_seconds,
+       COUNT(s.id) FILTER (WHERE s.ended_at IS NULL) > 0 AS in_session
+FROM game_progress p
+JOIN games g ON g.id = p.game_id
+LEFT JOIN play_sessions s ON s.game_id = g.id
+WHERE p.play_status = 'playing'
+GROUP BY g.id
+ORDER BY MAX(s.started_at) DESC NULLS LAST, g.titulo
+`
+
+type ListPlayingGamesRow struct {
+	ID            int32  `json:"id"`
+	Titulo        string `json:"titulo"`
+	Imagen        string `json:"imagen"`
+	PlayedSeconds int64  `json:"played_seconds"`
+	InSession     bool   `json:"in_session"`
+}
+
+func (q *Queries) ListPlayingGames(ctx context.Context) ([]ListPlayingGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPlayingGames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPlayingGamesRow
+	for rows.Next() {
+		var i ListPlayingGamesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Titulo,
+			&i.Imagen,
+			&i.PlayedSeconds,
+			&i.InSession,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceObservations = `-- name: ListPriceObservations :many
+SELECT id, game_id, price_cents, currency, source, observed_at FROM price_observations
+WHERE game_id = $1
+ORDER BY observed_at, id
+`
+
+func (q *Queries) ListPriceObservations(ctx context.Context, gameID int32) ([]PriceObservation, error) {
+	rows, err := q.db.QueryContext(ctx, listPriceObservations, gameID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PriceObservation
+	for rows.Next() {
+		var i PriceObservation
+		if err := rows.Scan(
+			&i.ID,
+			&i.GameID,
+			&i.PriceCents,
+			&i.Currency,
+			&i.Source,
+			&i.ObservedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPriceWatches = `-- name: ListPriceWatches :many
+SELECT w.game_id, g.titulo, w.target_cents, w.currency
+FROM price_watches w
+JOIN games g ON g.id = w.game_id
+WHERE g.estado = 'deseado'
+ORDER BY g.titulo
 `
 
-type GetGameRow struct {
-	ID          int32        `json:"id"`
-	Titulo      string       `json:"titulo"`
-	Descripcion string       `json:"descripcion"`
-	Categoria   string       `json:"categoria"`
-	Fecha       string       `json:"fecha"`
-	Estado      string       `json:"estado"`
-	Imagen      string       `json:"imagen"`
-	CreatedAt   sql.NullTime `json:"created_at"`
+type ListPriceWatchesRow struct {
+	GameID      int32  `json:"game_id"`
+	Titulo      string `json:"titulo"`
+	TargetCents int32  `json:"target_cents"`
+	Currency    string `json:"currency"`
 }
 
-func (q *Queries) GetGame(ctx context.Context, id int32) (GetGameRow, error) {
-	row := q.db.QueryRowContext(ctx, getGame, id)
-	var i GetGameRow
-	err := row.Scan(
-		&i.ID,
-		&i.Titulo,
-		&i.Descripcion,
-		&i.Categoria,
-		&i.Fecha,
-		&i.Estado,
-		&i.Imagen,
-		&i.CreatedAt,
-	)
-	return i, err
+func (q *Queries) ListPriceWatches(ctx context.Context) ([]ListPriceWatchesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPriceWatches)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPriceWatchesRow
+	for rows.Next() {
+		var i ListPriceWatchesRow
+		if err := rows.Scan(
+			&i.GameID,
+			&i.Titulo,
+			&i.TargetCents,
+			&i.Currency,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const listGames = `-- name: ListGames :many
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
+const listReleasesBetween = `-- name: ListReleasesBetween :many
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
 FROM games
-ORDER BY titulo
+WHERE fecha BETWEEN $1::date AND $2::date AND fecha_precision = 'day'
+ORDER BY games.fecha, titulo
 `
 
-type ListGamesRow struct {
-	ID          int32        `json:"id"`
-	Titulo      string       `json:"titulo"`
-	Descripcion string       `json:"descripcion"`
-	Categoria   string       `json:"categoria"`
-	Fecha       string       `json:"fecha"`
-	Estado      string       `json:"estado"`
-	Imagen      string       `json:"imagen"`
-	CreatedAt   sql.NullTime `json:"created_at"`
+type ListReleasesBetweenParams struct {
+	FromDate time.Time `json:"from_date"`
+	ToDate   time.Time `json:"to_date"`
 }
 
-func (q *Queries) ListGames(ctx context.Context) ([]ListGamesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listGames)
+type ListReleasesBetweenRow struct {
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+}
+
+func (q *Queries) ListReleasesBetween(ctx context.Context, arg ListReleasesBetweenParams) ([]ListReleasesBetweenRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReleasesBetween, arg.FromDate, arg.ToDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListGamesRow
+	var items []ListReleasesBetweenRow
 	for rows.Next() {
-		var i ListGamesRow
+		var i ListReleasesBetweenRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Titulo,
 			&i.Descripcion,
 			&i.Categoria,
+			&i.Plataforma,
 			&i.Fecha,
+			&i.FechaPrecision,
 			&i.Estado,
 			&i.Imagen,
 			&i.CreatedAt,
@@ -164,22 +1272,161 @@ func (q *Queries) ListGames(ctx context.Context) ([]ListGamesRow, error) {
 	return items, nil
 }
 
+const listSeries = `-- name: ListSeries :many
+SELECT s.id, s.name, COUNT(g.id) AS entries,
+       COUNT(g.id) FILTER (WHERE g.estado = 'comprado' OR EXISTS (SELECT 1 FROM game_editions ed WHERE ed.game_id = g.id AND ed.owned)) AS owned
+FROM series s
+LEFT JOIN series_entries e ON e.series_id = s.id
+LEFT JOIN games g ON g.id = e.game_id
+GROUP BY s.id
+ORDER BY s.name
+`
+
+type ListSeriesRow struct {
+	ID      int32  `json:"id"`
+	Name    string `json:"name"`
+	Entries int64  `json:"entries"`
+	Owned   int64  `json:"owned"`
+}
+
+// Una entrega cuenta como propia si el juego está comprado o se tiene
+// alguna de sus ediciones.
+func (q *Queries) ListSeries(ctx context.Context) ([]ListSeriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSeries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSeriesRow
+	for rows.Next() {
+		var i ListSeriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Entries,
+			&i.Owned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSeriesEntries = `-- name: ListSeriesEntries :many
+SELECT g.id, g.titulo, to_char(g.fecha, 'YYYY-MM-DD') AS fecha, g.fecha_precision, g.estado, g.imagen, e.position,
+       (g.estado = 'comprado' OR EXISTS (SELECT 1 FROM game_editions ed WHERE ed.game_id = g.id AND ed.owned))::bool AS owned
+FROM series_entries e
+JOIN games g ON g.id = e.game_id
+WHERE e.series_id = $1
+ORDER BY e.position, g.titulo
+`
+
+type ListSeriesEntriesRow struct {
+	ID             int32  `json:"id"`
+	Titulo         string `json:"titulo"`
+	Fecha          string `json:"fecha"`
+	FechaPrecision string `json:"fecha_precision"`
+	Estado         string `json:"estado"`
+	Imagen         string `json:"imagen"`
+	Position       int32  `json:"position"`
+	Owned          bool   `json:"owned"`
+}
+
+func (q *Queries) ListSeriesEntries(ctx context.Context, seriesID int32) ([]ListSeriesEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSeriesEntries, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSeriesEntriesRow
+	for rows.Next() {
+		var i ListSeriesEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Titulo,
+			&i.Fecha,
+			&i.FechaPrecision,
+			&i.Estado,
+			&i.Imagen,
+			&i.Position,
+			&i.Owned,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSimilarGames = `-- name: ListSimilarGames :many
+SELECT id, titulo, plataforma, similarity(normalize_title(titulo), normalize_title($1))::float8 AS score
+FROM games
+WHERE normalize_title(titulo) % normalize_title($1)
+ORDER BY score DESC
+LIMIT 5
+`
+
+type ListSimilarGamesRow struct {
+	ID         int32   `json:"id"`
+	Titulo     string  `json:"titulo"`
+	Plataforma string  `json:"plataforma"`
+	Score      float64 `json:"score"`
+}
+
+func (q *Queries) ListSimilarGames(ctx context.Context, titulo string) ([]ListSimilarGamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSimilarGames, titulo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSimilarGamesRow
+	for rows.Next() {
+		var i ListSimilarGamesRow
+		if err := rows.Scan(&i.ID, &i.Titulo, &i.Plataforma, &i.Score); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWantedGames = `-- name: ListWantedGames :many
-SELECT id, titulo, descripcion, categoria, to_char(fecha, 'YYYY-MM-DD') AS fecha, estado, imagen, created_at
+SELECT id, titulo, descripcion, categoria, plataforma, to_char(fecha, 'YYYY-MM-DD') AS fecha, fecha_precision, estado, imagen, created_at
 FROM games
 WHERE estado = 'deseado'
 ORDER BY titulo
 `
 
 type ListWantedGamesRow struct {
-	ID          int32        `json:"id"`
-	Titulo      string       `json:"titulo"`
-	Descripcion string       `json:"descripcion"`
-	Categoria   string       `json:"categoria"`
-	Fecha       string       `json:"fecha"`
-	Estado      string       `json:"estado"`
-	Imagen      string       `json:"imagen"`
-	CreatedAt   sql.NullTime `json:"created_at"`
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
 }
 
 func (q *Queries) ListWantedGames(ctx context.Context) ([]ListWantedGamesRow, error) {
@@ -196,7 +1443,9 @@ func (q *Queries) ListWantedGames(ctx context.Context) ([]ListWantedGamesRow, er
 			&i.Titulo,
 			&i.Descripcion,
 			&i.Categoria,
+			&i.Plataforma,
 			&i.Fecha,
+			&i.FechaPrecision,
 			&i.Estado,
 			&i.Imagen,
 			&i.CreatedAt,
@@ -214,21 +1463,127 @@ func (q *Queries) ListWantedGames(ctx context.Context) ([]ListWantedGamesRow, er
 	return items, nil
 }
 
+const markNotificationsRead = `-- name: MarkNotificationsRead :exec
+UPDATE notifications
+SET read_at = CURRENT_TIMESTAMP
+WHERE read_at IS NULL
+`
+
+func (q *Queries) MarkNotificationsRead(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, markNotificationsRead)
+	return err
+}
+
+const setSeriesEntry = `-- name: SetSeriesEntry :one
+INSERT INTO series_entries (game_id, series_id, position)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET series_id = EXCLUDED.series_id, position = EXCLUDED.position
+RETURNING game_id, series_id, position
+`
+
+type SetSeriesEntryParams struct {
+	GameID   int32 `json:"game_id"`
+	SeriesID int32 `json:"series_id"`
+	Position int32 `json:"position"`
+}
+
+func (q *Queries) SetSeriesEntry(ctx context.Context, arg SetSeriesEntryParams) (SeriesEntry, error) {
+	row := q.db.QueryRowContext(ctx, setSeriesEntry, arg.GameID, arg.SeriesID, arg.Position)
+	var i SeriesEntry
+	err := row.Scan(&i.GameID, &i.SeriesID, &i.Position)
+	return i, err
+}
+
+const spendByCategoria = `-- name: SpendByCategoria :many
+SELECT g.categoria, p.currency, SUM(p.price_cents)::bigint AS total_cents
+FROM purchases p
+JOIN games g ON g.id = p.game_id
+GROUP BY g.categoria, p.currency
+ORDER BY total_cents DESC, g.categoria, p.currency
+`
+
+type SpendByCategoriaRow struct {
+	Categoria  string `json:"categoria"`
+	Currency   string `json:"currency"`
+	TotalCents int64  `json:"total_cents"`
+}
+
+func (q *Queries) SpendByCategoria(ctx context.Context) ([]SpendByCategoriaRow, error) {
+	rows, err := q.db.QueryContext(ctx, spendByCategoria)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SpendByCategoriaRow
+	for rows.Next() {
+		var i SpendByCategoriaRow
+		if err := rows.Scan(&i.Categoria, &i.Currency, &i.TotalCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const spendByMonth = `-- name: SpendByMonth :many
+SELECT to_char(purchased_on, 'YYYY-MM') AS month, currency, SUM(price_cents)::bigint AS total_cents
+FROM purchases
+GROUP BY month, currency
+ORDER BY month, currency
+`
+
+type SpendByMonthRow struct {
+	Month      string `json:"month"`
+	Currency   string `json:"currency"`
+	TotalCents int64  `json:"total_cents"`
+}
+
+func (q *Queries) SpendByMonth(ctx context.Context) ([]SpendByMonthRow, error) {
+	rows, err := q.db.QueryContext(ctx, spendByMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SpendByMonthRow
+	for rows.Next() {
+		var i SpendByMonthRow
+		if err := rows.Scan(&i.Month, &i.Currency, &i.TotalCents); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateGame = `-- name: UpdateGame :one
 UPDATE games
-SET titulo = $2, descripcion = $3, categoria = $4, fecha = $5, estado = $6, imagen = $7
+SET titulo = $2, descripcion = $3, categoria = $4, plataforma = $5, fecha = $6, fecha_precision = $7, estado = $8, imagen = $9
 WHERE id = $1
-RETURNING id, titulo, descripcion, categoria, fecha, estado, imagen, created_at
+RETURNING id, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, created_at
 `
 
 type UpdateGameParams struct {
-	ID          int32     `json:"id"`
-	Titulo      string    `json:"titulo"`
-	Descripcion string    `json:"descripcion"`
-	Categoria   string    `json:"categoria"`
-	Fecha       time.Time `json:"fecha"`
-	Estado      string    `json:"estado"`
-	Imagen      string    `json:"imagen"`
+	ID             int32     `json:"id"`
+	Titulo         string    `json:"titulo"`
+	Descripcion    string    `json:"descripcion"`
+	Categoria      string    `json:"categoria"`
+	Plataforma     string    `json:"plataforma"`
+	Fecha          time.Time `json:"fecha"`
+	FechaPrecision string    `json:"fecha_precision"`
+	Estado         string    `json:"estado"`
+	Imagen         string    `json:"imagen"`
 }
 
 func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, error) {
@@ -237,7 +1592,9 @@ func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, e
 		arg.Titulo,
 		arg.Descripcion,
 		arg.Categoria,
+		arg.Plataforma,
 		arg.Fecha,
+		arg.FechaPrecision,
 		arg.Estado,
 		arg.Imagen,
 	)
@@ -247,7 +1604,39 @@ func (q *Queries) UpdateGame(ctx context.Context, arg UpdateGameParams) (Game, e
 		&i.Titulo,
 		&i.Descripcion,
 		&i.Categoria,
+		&i.Plataforma,
+		&i.Fecha,
+		&i.FechaPrecision,
+		&i.Estado,
+		&i.Imagen,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateGameCategory = `-- name: UpdateGameCategory :one
+UPDATE games
+SET categoria = $2
+WHERE id = $1
+RETURNING id, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, created_at
+`
+
+type UpdateGameCategoryParams struct {
+	ID        int32  `json:"id"`
+	Categoria string `json:"categoria"`
+}
+
+func (q *Queries) UpdateGameCategory(ctx context.Context, arg UpdateGameCategoryParams) (Game, error) {
+	row := q.db.QueryRowContext(ctx, updateGameCategory, arg.ID, arg.Categoria)
+	var i Game
+	err := row.Scan(
+		&i.ID,
+		&i.Titulo,
+		&i.Descripcion,
+		&i.Categoria,
+		&i.Plataforma,
 		&i.Fecha,
+		&i.FechaPrecision,
 		&i.Estado,
 		&i.Imagen,
 		&i.CreatedAt,
@@ -259,7 +1648,7 @@ const updateGameState = `-- name: UpdateGameState :one
 UPDATE games
 SET estado = $2
 WHERE id = $1
-RETURNING id, titulo, descripcion, categoria, fecha, estado, imagen, created_at
+RETURNING id, titulo, descripcion, categoria, plataforma, fecha, fecha_precision, estado, imagen, created_at
 `
 
 type UpdateGameStateParams struct {
@@ -275,10 +1664,140 @@ func (q *Queries) UpdateGameState(ctx context.Context, arg UpdateGameStateParams
 		&i.Titulo,
 		&i.Descripcion,
 		&i.Categoria,
+		&i.Plataforma,
 		&i.Fecha,
+		&i.FechaPrecision,
 		&i.Estado,
 		&i.Imagen,
 		&i.CreatedAt,
 	)
 	return i, err
 }
+
+const upsertGameEdition = `-- name: UpsertGameEdition :one
+INSERT INTO game_editions (game_id, edition, owned)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id, edition) DO UPDATE SET owned = EXCLUDED.owned
+RETURNING id, game_id, edition, owned, created_at
+`
+
+type UpsertGameEditionParams struct {
+	GameID  int32  `json:"game_id"`
+	Edition string `json:"edition"`
+	Owned   bool   `json:"owned"`
+}
+
+func (q *Queries) UpsertGameEdition(ctx context.Context, arg UpsertGameEditionParams) (GameEdition, error) {
+	row := q.db.QueryRowContext(ctx, upsertGameEdition, arg.GameID, arg.Edition, arg.Owned)
+	var i GameEdition
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.Edition,
+		&i.Owned,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertGameProgress = `-- name: UpsertGameProgress :one
+INSERT INTO game_progress (game_id, play_status, completed_on)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET play_status = EXCLUDED.play_status, completed_on = EXCLUDED.completed_on, updated_at = CURRENT_TIMESTAMP
+RETURNING game_id, play_status, completed_on, updated_at
+`
+
+type UpsertGameProgressParams struct {
+	GameID      int32        `json:"game_id"`
+	PlayStatus  string       `json:"play_status"`
+	CompletedOn sql.NullTime `json:"completed_on"`
+}
+
+func (q *Queries) UpsertGameProgress(ctx context.Context, arg UpsertGameProgressParams) (GameProgress, error) {
+	row := q.db.QueryRowContext(ctx, upsertGameProgress, arg.GameID, arg.PlayStatus, arg.CompletedOn)
+	var i GameProgress
+	err := row.Scan(
+		&i.GameID,
+		&i.PlayStatus,
+		&i.CompletedOn,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertPriceWatch = `-- name: UpsertPriceWatch :one
+INSERT INTO price_watches (game_id, target_cents, currency)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id) DO UPDATE SET target_cents = EXCLUDED.target_cents, currency = EXCLUDED.currency
+RETURNING game_id, target_cents, currency, created_at
+`
+
+type UpsertPriceWatchParams struct {
+	GameID      int32  `json:"game_id"`
+	TargetCents int32  `json:"target_cents"`
+	Currency    string `json:"currency"`
+}
+
+func (q *Queries) UpsertPriceWatch(ctx context.Context, arg UpsertPriceWatchParams) (PriceWatch, error) {
+	row := q.db.QueryRowContext(ctx, upsertPriceWatch,
+		arg.GameID,
+		arg.TargetCents,
+		arg.Currency,
+	)
+	var i PriceWatch
+	err := row.Scan(
+		&i.GameID,
+		&i.TargetCents,
+		&i.Currency,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertReview = `-- name: UpsertReview :one
+INSERT INTO reviews (game_id, reviewer, rating, body)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (game_id, reviewer) DO UPDATE SET rating = EXCLUDED.rating, body = EXCLUDED.body, updated_at = CURRENT_TIMESTAMP
+RETURNING id, game_id, reviewer, rating, body, created_at, updated_at
+`
+
+type UpsertReviewParams struct {
+	GameID   int32  `json:"game_id"`
+	Reviewer string `json:"reviewer"`
+	Rating   int32  `json:"rating"`
+	Body     string `json:"body"`
+}
+
+func (q *Queries) UpsertReview(ctx context.Context, arg UpsertReviewParams) (Review, error) {
+	row := q.db.QueryRowContext(ctx, upsertReview,
+		arg.GameID,
+		arg.Reviewer,
+		arg.Rating,
+		arg.Body,
+	)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.GameID,
+		&i.Reviewer,
+		&i.Rating,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertTag = `-- name: UpsertTag :one
+INSERT INTO tags (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, name
+`
+
+func (q *Queries) UpsertTag(ctx context.Context, name string) (Tag, error) {
+	row := q.db.QueryRowContext(ctx, upsertTag, name)
+	var i Tag
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
@@ -0,0 +1,704 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+	db_connect "tp-web/db"
+	"tp-web/db/dbtest"
+	datos "tp-web/db/sqlc"
+
+	"github.com/lib/pq"
+)
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func newGame(titulo, estado string) datos.CreateGameParams {
+	return datos.CreateGameParams{
+		Titulo:         titulo,
+		Descripcion:    "Descripción de " + titulo,
+		Categoria:      "Accion",
+		Fecha:          date("2024-09-10"),
+		FechaPrecision: "day",
+		Estado:         estado,
+		Imagen:         "img/test.png",
+	}
+}
+
+func onPlatform(arg datos.CreateGameParams, plataforma string) datos.CreateGameParams {
+	arg.Plataforma = plataforma
+	return arg
+}
+
+func mustCreate(t *testing.T, q *datos.Queries, arg datos.CreateGameParams) datos.CreateGameRow {
+	t.Helper()
+	game, err := q.CreateGame(context.Background(), arg)
+	if err != nil {
+		t.Fatalf("CreateGame(%q): %v", arg.Titulo, err)
+	}
+	return game
+}
+
+func pqCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code.Name()
+	}
+	return ""
+}
+
+func TestCreateGame(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		arg      datos.CreateGameParams
+		wantCode string
+	}{
+		{"valid", newGame("FIFA25", "comprado"), ""},
+		{"each valid estado", newGame("Call of Duty", "none"), ""},
+		{"invalid estado violates CHECK", newGame("Battlefield 5", "perdido"), "check_violation"},
+		{"titulo longer than 150", newGame(strings.Repeat("x", 151), "none"), "string_data_right_truncation"},
+		{"same normalized titulo", newGame("  fífa25 ", "deseado"), "unique_violation"},
+		{"same titulo on another plataforma", onPlatform(newGame("FIFA25", "deseado"), "PS5"), ""},
+		{"same titulo twice on a plataforma", onPlatform(newGame("fifa25", "none"), "PS5"), "unique_violation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := q.CreateGame(ctx, tt.arg)
+			if tt.wantCode != "" {
+				if got := pqCode(err); got != tt.wantCode {
+					t.Fatalf("err = %v (%s), want %s", err, got, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if game.ID == 0 || game.Titulo != tt.arg.Titulo || game.Plataforma != tt.arg.Plataforma || !game.CreatedAt.Valid {
+				t.Errorf("game = %+v", game)
+			}
+			if game.Fecha != "2024-09-10" {
+				t.Errorf("Fecha = %q, want to_char format 2024-09-10", game.Fecha)
+			}
+		})
+	}
+}
+
+func TestGetGame(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	created := mustCreate(t, q, newGame("FIFA26", "deseado"))
+
+	tests := []struct {
+		name    string
+		id      int32
+		wantErr error
+	}{
+		{"existing", created.ID, nil},
+		{"missing", created.ID + 100, sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := q.GetGame(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (game.Titulo != "FIFA26" || game.Fecha != "2024-09-10") {
+				t.Errorf("game = %+v", game)
+			}
+		})
+	}
+}
+
+func TestListGamesAndListWantedGames(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+
+	mustCreate(t, q, newGame("FIFA26", "deseado"))
+	mustCreate(t, q, newGame("Battlefield 5", "none"))
+	mustCreate(t, q, newGame("Call of Duty", "deseado"))
+
+	games, err := q.ListGames(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Battlefield 5", "Call of Duty", "FIFA26"}
+	if len(games) != len(want) {
+		t.Fatalf("len = %d, want %d", len(games), len(want))
+	}
+	for i, g := range games {
+		if g.Titulo != want[i] {
+			t.Errorf("games[%d] = %q, want %q", i, g.Titulo, want[i])
+		}
+		if g.Fecha != "2024-09-10" {
+			t.Errorf("games[%d].Fecha = %q", i, g.Fecha)
+		}
+	}
+
+	wanted, err := q.ListWantedGames(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(wanted) != 2 || wanted[0].Titulo != "Call of Duty" || wanted[1].Titulo != "FIFA26" {
+		t.Errorf("wanted = %+v", wanted)
+	}
+}
+
+func TestListSimilarGames(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	mustCreate(t, q, newGame("FIFA25", "none"))
+	mustCreate(t, q, newGame("Battlefield 5", "none"))
+
+	tests := []struct {
+		titulo string
+		want   string
+	}{
+		{"FIFA 25", "FIFA25"},
+		{"fífa25", "FIFA25"},
+		{"Battlefield", "Battlefield 5"},
+		{"Zelda", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.titulo, func(t *testing.T) {
+			similar, err := q.ListSimilarGames(ctx, tt.titulo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.want == "" {
+				if len(similar) != 0 {
+					t.Errorf("similar = %+v, want none", similar)
+				}
+				return
+			}
+			if len(similar) == 0 || similar[0].Titulo != tt.want || similar[0].Score <= 0 {
+				t.Errorf("similar = %+v, want %q first", similar, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountGamesByEstado(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	mustCreate(t, q, newGame("FIFA25", "deseado"))
+	mustCreate(t, q, newGame("Battlefield 5", "none"))
+	mustCreate(t, q, newGame("Call of Duty", "deseado"))
+
+	counts, err := q.CountGamesByEstado(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []datos.CountGamesByEstadoRow{{Estado: "deseado", Total: 2}, {Estado: "none", Total: 1}}
+	if len(counts) != len(want) || counts[0] != want[0] || counts[1] != want[1] {
+		t.Errorf("counts = %+v, want %+v", counts, want)
+	}
+}
+
+func TestLibraryStatsQueries(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	mustCreate(t, q, newGame("FIFA25", "deseado"))
+	mustCreate(t, q, newGame("Battlefield 5", "none"))
+
+	categorias, err := q.CountGamesByCategoria(ctx)
+	if err != nil || len(categorias) != 1 || categorias[0].Total != 2 {
+		t.Errorf("CountGamesByCategoria = %+v, %v", categorias, err)
+	}
+	years, err := q.CountGamesByReleaseYear(ctx)
+	if err != nil || len(years) != 1 || years[0].Total != 2 {
+		t.Errorf("CountGamesByReleaseYear = %+v, %v", years, err)
+	}
+	months, err := q.CountGamesAddedByMonth(ctx)
+	if err != nil || len(months) != 1 || months[0].Month != time.Now().Format("2006-01") {
+		t.Errorf("CountGamesAddedByMonth = %+v, %v", months, err)
+	}
+}
+
+func TestPurchases(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("FIFA25", "comprado"))
+
+	for _, price := range []int32{5999, 1000} {
+		_, err := q.CreatePurchase(ctx, datos.CreatePurchaseParams{
+			GameID:      game.ID,
+			PriceCents:  price,
+			Currency:    "USD",
+			Store:       "Steam",
+			PurchasedOn: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Format:      "digital",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	purchases, err := q.ListGamePurchases(ctx, game.ID)
+	if err != nil || len(purchases) != 2 || purchases[0].PurchasedOn != "2025-03-01" {
+		t.Errorf("ListGamePurchases = %+v, %v", purchases, err)
+	}
+
+	byMonth, err := q.SpendByMonth(ctx)
+	if err != nil || len(byMonth) != 1 || byMonth[0].Month != "2025-03" || byMonth[0].TotalCents != 6999 {
+		t.Errorf("SpendByMonth = %+v, %v", byMonth, err)
+	}
+	byCategoria, err := q.SpendByCategoria(ctx)
+	if err != nil || len(byCategoria) != 1 || byCategoria[0].TotalCents != 6999 {
+		t.Errorf("SpendByCategoria = %+v, %v", byCategoria, err)
+	}
+
+	_, err = q.CreatePurchase(ctx, datos.CreatePurchaseParams{GameID: game.ID, PriceCents: 1, Currency: "USD", Store: "x", PurchasedOn: time.Now(), Format: "cartucho"})
+	if pqCode(err) != "check_violation" {
+		t.Errorf("invalid format err = %v", err)
+	}
+}
+
+func TestPriceWatches(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	wanted := mustCreate(t, q, newGame("Hades", "deseado"))
+	bought := mustCreate(t, q, newGame("Celeste", "comprado"))
+
+	for _, g := range []datos.CreateGameRow{wanted, bought} {
+		if _, err := q.UpsertPriceWatch(ctx, datos.UpsertPriceWatchParams{GameID: g.ID, TargetCents: 2000, Currency: "USD"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	watch, err := q.UpsertPriceWatch(ctx, datos.UpsertPriceWatchParams{GameID: wanted.ID, TargetCents: 1500, Currency: "EUR"})
+	if err != nil || watch.TargetCents != 1500 || watch.Currency != "EUR" {
+		t.Errorf("UpsertPriceWatch = %+v, %v", watch, err)
+	}
+	watches, err := q.ListPriceWatches(ctx)
+	if err != nil || len(watches) != 1 || watches[0].Titulo != "Hades" {
+		t.Errorf("ListPriceWatches = %+v, %v, want only the wanted game", watches, err)
+	}
+
+	for _, cents := range []int32{1999, 1499} {
+		if _, err := q.CreatePriceObservation(ctx, datos.CreatePriceObservationParams{GameID: wanted.ID, PriceCents: cents, Currency: "EUR", Source: "manual"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	latest, err := q.GetLatestPriceObservation(ctx, wanted.ID)
+	if err != nil || latest.PriceCents != 1499 {
+		t.Errorf("GetLatestPriceObservation = %+v, %v", latest, err)
+	}
+	if observations, err := q.ListPriceObservations(ctx, wanted.ID); err != nil || len(observations) != 2 || observations[0].PriceCents != 1999 {
+		t.Errorf("ListPriceObservations = %+v, %v", observations, err)
+	}
+
+	if _, err := q.CreateNotification(ctx, datos.CreateNotificationParams{GameID: wanted.ID, Kind: "price_below_target", PriceCents: 1499, Currency: "EUR"}); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := q.CountUnreadNotifications(ctx); err != nil || count != 1 {
+		t.Errorf("CountUnreadNotifications = %d, %v", count, err)
+	}
+	if err := q.MarkNotificationsRead(ctx); err != nil {
+		t.Fatal(err)
+	}
+	notifications, err := q.ListNotifications(ctx)
+	if err != nil || len(notifications) != 1 || notifications[0].Titulo != "Hades" || !notifications[0].ReadAt.Valid {
+		t.Errorf("ListNotifications = %+v, %v", notifications, err)
+	}
+
+	if err := q.DeletePriceWatch(ctx, wanted.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.GetPriceWatch(ctx, wanted.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetPriceWatch after delete err = %v", err)
+	}
+}
+
+func TestPlayTracking(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("Hades", "comprado"))
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	if _, err := q.UpsertGameProgress(ctx, datos.UpsertGameProgressParams{GameID: game.ID, PlayStatus: "playing"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := q.CreatePlaySession(ctx, datos.CreatePlaySessionParams{GameID: game.ID, StartedAt: start, EndedAt: sql.NullTime{Time: start.Add(90 * time.Minute), Valid: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.CreatePlaySession(ctx, datos.CreatePlaySessionParams{GameID: game.ID, StartedAt: start.Add(24 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = q.CreatePlaySession(ctx, datos.CreatePlaySessionParams{GameID: game.ID, StartedAt: start.Add(48 * time.Hour)})
+	if pqCode(err) != "unique_violation" {
+		t.Errorf("second open session err = %v", err)
+	}
+
+	playing, err := q.ListPlayingGames(ctx)
+	if err != nil || len(playing) != 1 || playing[0].PlayedSeconds != 5400 || !playing[0].InSession {
+		t.Errorf("ListPlayingGames = %+v, %v", playing, err)
+	}
+
+	ended, err := q.EndPlaySession(ctx, datos.EndPlaySessionParams{GameID: game.ID, EndedAt: sql.NullTime{Time: start.Add(25 * time.Hour), Valid: true}})
+	if err != nil || !ended.EndedAt.Valid {
+		t.Errorf("EndPlaySession = %+v, %v", ended, err)
+	}
+	if _, err := q.EndPlaySession(ctx, datos.EndPlaySessionParams{GameID: game.ID, EndedAt: sql.NullTime{Time: start, Valid: true}}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("EndPlaySession without an open session err = %v", err)
+	}
+	sessions, err := q.ListPlaySessions(ctx, game.ID)
+	if err != nil || len(sessions) != 2 || !sessions[0].StartedAt.Equal(start.Add(24*time.Hour)) {
+		t.Errorf("ListPlaySessions = %+v, %v", sessions, err)
+	}
+
+	progress, err := q.UpsertGameProgress(ctx, datos.UpsertGameProgressParams{GameID: game.ID, PlayStatus: "finished", CompletedOn: sql.NullTime{Time: date("2025-04-02"), Valid: true}})
+	if err != nil || progress.PlayStatus != "finished" {
+		t.Errorf("UpsertGameProgress = %+v, %v", progress, err)
+	}
+	if got, err := q.GetGameProgress(ctx, game.ID); err != nil || got.CompletedOn.Time.Format("2006-01-02") != "2025-04-02" {
+		t.Errorf("GetGameProgress = %+v, %v", got, err)
+	}
+	if playing, _ := q.ListPlayingGames(ctx); len(playing) != 0 {
+		t.Errorf("ListPlayingGames = %+v, want none after finishing", playing)
+	}
+
+	_, err = q.UpsertGameProgress(ctx, datos.UpsertGameProgressParams{GameID: game.ID, PlayStatus: "jugando"})
+	if pqCode(err) != "check_violation" {
+		t.Errorf("invalid play status err = %v", err)
+	}
+}
+
+func TestReviews(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	hades := mustCreate(t, q, newGame("Hades", "comprado"))
+	mustCreate(t, q, newGame("Celeste", "comprado"))
+
+	for _, arg := range []datos.UpsertReviewParams{
+		{GameID: hades.ID, Reviewer: "ana", Rating: 7},
+		{GameID: hades.ID, Reviewer: "ana", Rating: 9, Body: "**Muy bueno**"},
+		{GameID: hades.ID, Reviewer: "beto", Rating: 8},
+	} {
+		if _, err := q.UpsertReview(ctx, arg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reviews, err := q.ListGameReviews(ctx, hades.ID)
+	if err != nil || len(reviews) != 2 || reviews[0].Reviewer != "beto" || reviews[1].Body != "**Muy bueno**" {
+		t.Errorf("ListGameReviews = %+v, %v", reviews, err)
+	}
+
+	games, err := q.ListGames(ctx)
+	if err != nil || len(games) != 2 || games[0].ReviewCount != 0 || games[1].AvgRating != 8.5 || games[1].ReviewCount != 2 {
+		t.Errorf("ListGames = %+v, %v", games, err)
+	}
+
+	_, err = q.UpsertReview(ctx, datos.UpsertReviewParams{GameID: hades.ID, Reviewer: "caro", Rating: 11})
+	if pqCode(err) != "check_violation" {
+		t.Errorf("rating out of range err = %v", err)
+	}
+
+	if err := q.DeleteReview(ctx, datos.DeleteReviewParams{ID: reviews[0].ID, GameID: hades.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if reviews, _ := q.ListGameReviews(ctx, hades.ID); len(reviews) != 1 {
+		t.Errorf("reviews = %d after delete, want 1", len(reviews))
+	}
+}
+
+func TestListReleasesBetween(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	for _, g := range []struct{ titulo, fecha string }{
+		{"Hades", "2025-03-31"}, {"Celeste", "2025-03-01"}, {"Balatro", "2025-03-01"}, {"Tunic", "2025-04-01"},
+	} {
+		arg := newGame(g.titulo, "deseado")
+		arg.Fecha = date(g.fecha)
+		mustCreate(t, q, arg)
+	}
+
+	games, err := q.ListReleasesBetween(ctx, datos.ListReleasesBetweenParams{FromDate: date("2025-03-01"), ToDate: date("2025-03-31")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, g := range games {
+		got = append(got, g.Fecha+" "+g.Titulo)
+	}
+	want := []string{"2025-03-01 Balatro", "2025-03-01 Celeste", "2025-03-31 Hades"}
+	if !slices.Equal(got, want) {
+		t.Errorf("ListReleasesBetween = %v, want %v", got, want)
+	}
+}
+
+func TestReleasePrecision(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	month := newGame("Hades II", "deseado")
+	month.Fecha, month.FechaPrecision = date("2025-03-31"), "month"
+	mustCreate(t, q, month)
+	tba := newGame("Elder Scrolls VI", "deseado")
+	tba.Fecha, tba.FechaPrecision = date("9999-12-31"), "tba"
+	mustCreate(t, q, tba)
+
+	// En la grilla del calendario solo entran las fechas exactas
+	games, err := q.ListReleasesBetween(ctx, datos.ListReleasesBetweenParams{FromDate: date("2025-03-01"), ToDate: date("2025-03-31")})
+	if err != nil || len(games) != 0 {
+		t.Errorf("ListReleasesBetween = %+v, %v", games, err)
+	}
+	// Los por anunciar no cuentan como del año 9999
+	years, err := q.CountGamesByReleaseYear(ctx)
+	if err != nil || len(years) != 1 || years[0].Year != 2025 {
+		t.Errorf("CountGamesByReleaseYear = %+v, %v", years, err)
+	}
+
+	bad := newGame("Silksong", "deseado")
+	bad.FechaPrecision = "week"
+	if _, err := q.CreateGame(ctx, bad); pqCode(err) != "check_violation" {
+		t.Errorf("invalid precision err = %v", err)
+	}
+}
+
+func TestSeries(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	fifa25 := mustCreate(t, q, newGame("FIFA25", "comprado"))
+	fifa26 := mustCreate(t, q, newGame("FIFA26", "deseado"))
+	mustCreate(t, q, newGame("Celeste", "none"))
+
+	fifa, err := q.CreateSeries(ctx, "FIFA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.CreateSeries(ctx, "FIFA"); pqCode(err) != "unique_violation" {
+		t.Errorf("duplicate series err = %v", err)
+	}
+	for _, arg := range []datos.SetSeriesEntryParams{
+		{GameID: fifa26.ID, SeriesID: fifa.ID, Position: 1},
+		{GameID: fifa25.ID, SeriesID: fifa.ID, Position: 1},
+		{GameID: fifa26.ID, SeriesID: fifa.ID, Position: 2}, // cambia la posición
+	} {
+		if _, err := q.SetSeriesEntry(ctx, arg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := q.SetSeriesEntry(ctx, datos.SetSeriesEntryParams{GameID: fifa25.ID, SeriesID: fifa.ID, Position: 0}); pqCode(err) != "check_violation" {
+		t.Errorf("position 0 err = %v", err)
+	}
+
+	entries, err := q.ListSeriesEntries(ctx, fifa.ID)
+	if err != nil || len(entries) != 2 || entries[0].ID != fifa25.ID || !entries[0].Owned || entries[1].Owned {
+		t.Errorf("ListSeriesEntries = %+v, %v", entries, err)
+	}
+
+	// Tener una edición cuenta como tener la entrega
+	for _, arg := range []datos.UpsertGameEditionParams{
+		{GameID: fifa26.ID, Edition: "goty"},
+		{GameID: fifa26.ID, Edition: "standard"},
+		{GameID: fifa26.ID, Edition: "goty", Owned: true},
+	} {
+		if _, err := q.UpsertGameEdition(ctx, arg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := q.UpsertGameEdition(ctx, datos.UpsertGameEditionParams{GameID: fifa26.ID, Edition: "platinum"}); pqCode(err) != "check_violation" {
+		t.Errorf("invalid edition err = %v", err)
+	}
+	editions, err := q.ListGameEditions(ctx, fifa26.ID)
+	if err != nil || len(editions) != 2 || editions[0].Edition != "standard" || !editions[1].Owned {
+		t.Errorf("ListGameEditions = %+v, %v", editions, err)
+	}
+	series, err := q.ListSeries(ctx)
+	if err != nil || len(series) != 1 || series[0].Entries != 2 || series[0].Owned != 2 {
+		t.Errorf("ListSeries = %+v, %v", series, err)
+	}
+
+	games, err := q.ListGames(ctx)
+	if err != nil || len(games) != 3 || games[0].SeriesID != 0 || games[2].SeriesName != "FIFA" || games[2].SeriesPosition != 2 {
+		t.Errorf("ListGames = %+v, %v", games, err)
+	}
+
+	if err := q.DeleteSeries(ctx, fifa.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.GetGameSeries(ctx, fifa25.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("entry left after deleting the series: %v", err)
+	}
+}
+
+func TestGetSchemaVersion(t *testing.T) {
+	db := dbtest.New(t)
+
+	version, err := datos.New(db).GetSchemaVersion(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != db_connect.SchemaVersion {
+		t.Errorf("schema version = %d, code expects %d", version, db_connect.SchemaVersion)
+	}
+	if err := db_connect.Ready(context.Background(), db); err != nil {
+		t.Errorf("Ready: %v", err)
+	}
+}
+
+func TestUpdateGameAndUpdateGameState(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("FIFA25", "none"))
+
+	updated, err := q.UpdateGame(ctx, datos.UpdateGameParams{
+		ID:             game.ID,
+		Titulo:         "FIFA 25 Ultimate",
+		Descripcion:    "Simulador de Fútbol",
+		Categoria:      "Deporte",
+		Fecha:          date("2024-09-27"),
+		FechaPrecision: "day",
+		Estado:         "comprado",
+		Imagen:         "img/fifa25.png",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Titulo != "FIFA 25 Ultimate" || !updated.Fecha.Equal(date("2024-09-27")) {
+		t.Errorf("updated = %+v", updated)
+	}
+
+	tests := []struct {
+		estado   string
+		wantCode string
+	}{
+		{"deseado", ""},
+		{"none", ""},
+		{"completado", "check_violation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.estado, func(t *testing.T) {
+			g, err := q.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: game.ID, Estado: tt.estado})
+			if got := pqCode(err); got != tt.wantCode {
+				t.Fatalf("err = %v, want code %q", err, tt.wantCode)
+			}
+			if tt.wantCode == "" && g.Estado != tt.estado {
+				t.Errorf("Estado = %q, want %q", g.Estado, tt.estado)
+			}
+		})
+	}
+
+	if _, err := q.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: game.ID + 100, Estado: "none"}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("missing game err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestGameRevisions(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("FIFA25", "none"))
+
+	for i, titulo := range []string{"FIFA25", "FIFA 25", "FIFA25"} {
+		arg := datos.CreateGameRevisionParams{
+			GameID:         game.ID,
+			Titulo:         titulo,
+			Descripcion:    "d",
+			Categoria:      "Deporte",
+			Fecha:          date("2024-09-10"),
+			FechaPrecision: "day",
+			Estado:         "none",
+			Imagen:         "img/fifa25.png",
+		}
+		if i == 2 {
+			arg.RevertedFrom = sql.NullInt32{Int32: 1, Valid: true}
+		}
+		rev, err := q.CreateGameRevision(ctx, arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rev.Revision != int32(i+1) {
+			t.Errorf("Revision = %d, want %d", rev.Revision, i+1)
+		}
+	}
+
+	revisions, err := q.ListGameRevisions(ctx, game.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != 3 || revisions[0].Revision != 3 || revisions[0].Fecha != "2024-09-10" || revisions[0].RevertedFrom.Int32 != 1 {
+		t.Errorf("revisions = %+v", revisions)
+	}
+
+	rev, err := q.GetGameRevision(ctx, datos.GetGameRevisionParams{GameID: game.ID, Revision: 2})
+	if err != nil || rev.Titulo != "FIFA 25" {
+		t.Errorf("GetGameRevision = %+v, %v", rev, err)
+	}
+	if _, err := q.GetGameRevision(ctx, datos.GetGameRevisionParams{GameID: game.ID, Revision: 9}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("missing revision err = %v", err)
+	}
+
+	_, err = q.CreateGameRevision(ctx, datos.CreateGameRevisionParams{GameID: game.ID + 100, Titulo: "x", Descripcion: "x", Categoria: "x", Fecha: date("2024-01-01"), FechaPrecision: "day", Estado: "none", Imagen: "x"})
+	if got := pqCode(err); got != "foreign_key_violation" {
+		t.Errorf("revision for missing game err = %v, want foreign_key_violation", err)
+	}
+}
+
+func TestTags(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("FIFA25", "none"))
+
+	rpg, err := q.UpsertTag(ctx, "rpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := q.UpsertTag(ctx, "rpg")
+	if err != nil || again.ID != rpg.ID {
+		t.Fatalf("UpsertTag twice = %+v, %v; want id %d", again, err, rpg.ID)
+	}
+	indie, _ := q.UpsertTag(ctx, "indie")
+
+	for _, tag := range []datos.Tag{rpg, indie, rpg} {
+		if err := q.AddGameTag(ctx, datos.AddGameTagParams{GameID: game.ID, TagID: tag.ID}); err != nil {
+			t.Fatalf("AddGameTag(%s): %v", tag.Name, err)
+		}
+	}
+	tags, err := q.ListGameTags(ctx, game.ID)
+	if err != nil || len(tags) != 2 || tags[0] != "indie" || tags[1] != "rpg" {
+		t.Errorf("ListGameTags = %v, %v", tags, err)
+	}
+
+	if _, err := q.UpsertTag(ctx, strings.Repeat("x", 31)); pqCode(err) != "string_data_right_truncation" {
+		t.Errorf("long tag err = %v", err)
+	}
+	if err := q.AddGameTag(ctx, datos.AddGameTagParams{GameID: game.ID + 100, TagID: rpg.ID}); pqCode(err) != "foreign_key_violation" {
+		t.Errorf("tag for missing game err = %v", err)
+	}
+
+	q.DeleteGame(ctx, game.ID)
+	if tags, _ := q.ListGameTags(ctx, game.ID); len(tags) != 0 {
+		t.Errorf("game tags were not deleted in cascade: %v", tags)
+	}
+}
+
+func TestDeleteGame(t *testing.T) {
+	q := datos.New(dbtest.New(t))
+	ctx := context.Background()
+	game := mustCreate(t, q, newGame("FIFA25", "none"))
+	if _, err := q.CreateGameRevision(ctx, datos.CreateGameRevisionParams{GameID: game.ID, Titulo: "FIFA25", Descripcion: "d", Categoria: "c", Fecha: date("2024-09-10"), FechaPrecision: "day", Estado: "none", Imagen: "i"}); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := q.DeleteGame(ctx, game.ID)
+	if err != nil || deleted.ID != game.ID {
+		t.Fatalf("DeleteGame = %+v, %v", deleted, err)
+	}
+	if revisions, _ := q.ListGameRevisions(ctx, game.ID); len(revisions) != 0 {
+		t.Errorf("revisions were not deleted in cascade: %+v", revisions)
+	}
+	if _, err := q.DeleteGame(ctx, game.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("second delete err = %v, want sql.ErrNoRows", err)
+	}
+}
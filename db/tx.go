@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maxTxAttempts es cuántas veces RunInTx intenta una transacción que falla
+// por un conflicto de serialización o un deadlock.
+const maxTxAttempts = 3
+
+// RunInTx ejecuta fn dentro de una transacción serializable: si fn devuelve
+// nil hace commit y si no, rollback. Cuando Postgres aborta la transacción
+// por un conflicto con otra (serialization_failure o deadlock_detected) la
+// vuelve a ejecutar desde el principio, así que fn no debe tener efectos
+// fuera de la base.
+func RunInTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := runOnce(ctx, db, fn)
+		if err == nil || !isRetryable(err) || attempt == maxTxAttempts {
+			return err
+		}
+
+		wait := time.Duration(attempt)*20*time.Millisecond + rand.N(20*time.Millisecond)
+		slog.WarnContext(ctx, "Conflicto en la transacción, reintentando", "intento", attempt, "espera", wait.String(), "err", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func runOnce(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+				err = fmt.Errorf("%w (rollback: %v)", err, rbErr)
+			}
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// isRetryable indica si err es un conflicto entre transacciones que se
+// resuelve volviendo a intentar.
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	switch pqErr.Code.Name() {
+	case "serialization_failure", "deadlock_detected":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	db_connect "tp-web/db"
+	"tp-web/db/dbtest"
+
+	"github.com/lib/pq"
+)
+
+func countGames(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM games").Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+const insertGame = `INSERT INTO games (titulo, descripcion, categoria, fecha, estado, imagen)
+VALUES ($1, 'd', 'c', '2024-01-01', 'none', 'i')`
+
+func TestRunInTx(t *testing.T) {
+	db := dbtest.New(t)
+	ctx := context.Background()
+
+	// Commit
+	err := db_connect.RunInTx(ctx, db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, insertGame, "FIFA25")
+		return err
+	})
+	if err != nil || countGames(t, db) != 1 {
+		t.Fatalf("commit: err = %v, games = %d", err, countGames(t, db))
+	}
+
+	// Rollback: el error de fn se devuelve y no queda nada
+	errFailed := errors.New("failed")
+	err = db_connect.RunInTx(ctx, db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, insertGame, "PES"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) || countGames(t, db) != 1 {
+		t.Fatalf("rollback: err = %v, games = %d", err, countGames(t, db))
+	}
+
+	// Conflicto de serialización: se reintenta
+	attempts := 0
+	err = db_connect.RunInTx(ctx, db, func(tx *sql.Tx) error {
+		attempts++
+		if attempts == 1 {
+			return &pq.Error{Code: "40001"}
+		}
+		_, err := tx.ExecContext(ctx, insertGame, "Battlefield 5")
+		return err
+	})
+	if err != nil || attempts != 2 || countGames(t, db) != 2 {
+		t.Fatalf("retry: err = %v, attempts = %d, games = %d", err, attempts, countGames(t, db))
+	}
+
+	// Otros errores no se reintentan
+	attempts = 0
+	db_connect.RunInTx(ctx, db, func(tx *sql.Tx) error {
+		attempts++
+		return &pq.Error{Code: "23505"}
+	})
+	if attempts != 1 {
+		t.Errorf("unique_violation was retried %d times", attempts)
+	}
+}
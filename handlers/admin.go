@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"tp-web/logging"
+)
+
+// LogLevel devuelve el nivel de log actual.
+func (h *Handler) LogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"level": strings.ToLower(logging.Level.Level().String())})
+}
+
+// SetLogLevel cambia el nivel de log sin reiniciar el servidor. Recibe
+// level=debug|info|warn|error como formulario o {"level": "..."} en JSON.
+func (h *Handler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var value string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var body struct {
+			Level string `json:"level"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "JSON inválido"})
+			return
+		}
+		value = body.Level
+	} else {
+		value = r.FormValue("level")
+	}
+
+	level, err := logging.ParseLevel(value)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	previous := logging.Level.Level()
+	logging.Level.Set(level)
+	slog.WarnContext(r.Context(), "Nivel de log cambiado", "anterior", previous.String(), "nuevo", level.String())
+
+	h.LogLevel(w, r)
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/i18n"
+	"tp-web/ical"
+	"tp-web/validation"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// maxUpcomingDays limita ?days= en /calendar.
+const maxUpcomingDays = 365
+
+// Calendar muestra la grilla de lanzamientos de un mes (?month=YYYY-MM, por
+// defecto el actual) y los deseados que salen en los próximos días (?days=N,
+// por defecto UpcomingDays), o los devuelve en JSON si se pide.
+func (h *Handler) Calendar(w http.ResponseWriter, r *http.Request) {
+	y, m, d := time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	month := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	if v := r.URL.Query().Get("month"); v != "" {
+		parsed, err := time.Parse("2006-01", v)
+		if err != nil {
+			http.Error(w, "invalid month", http.StatusBadRequest)
+			return
+		}
+		month = parsed
+	}
+	days := h.UpcomingDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxUpcomingDays {
+			http.Error(w, "invalid days", http.StatusBadRequest)
+			return
+		}
+		days = n
+	}
+
+	releases, err := h.Games.ListReleasesBetween(r.Context(), datos.ListReleasesBetweenParams{
+		FromDate: month,
+		ToDate:   month.AddDate(0, 1, -1),
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar los lanzamientos del mes", "err", err, "mes", month.Format("2006-01"))
+		writeDBError(w, r, err)
+		return
+	}
+
+	upcoming, err := h.Games.ListReleasesBetween(r.Context(), datos.ListReleasesBetweenParams{
+		FromDate: today,
+		ToDate:   today.AddDate(0, 0, days),
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar los próximos lanzamientos", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	upcoming = slices.DeleteFunc(upcoming, func(g datos.ListReleasesBetweenRow) bool { return g.Estado != "deseado" })
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, struct {
+			Month    string                         `json:"month"`
+			Releases []datos.ListReleasesBetweenRow `json:"releases"`
+			Days     int                            `json:"days"`
+			Upcoming []datos.ListReleasesBetweenRow `json:"upcoming"`
+		}{month.Format("2006-01"), releases, days, upcoming})
+		return
+	}
+
+	cal := views.ReleaseCalendar{Month: month, Today: today, Releases: releases, Days: days, Upcoming: upcoming}
+	templ.Handler(views.Layout(views.Calendar(cal))).ServeHTTP(w, r)
+}
+
+// CalendarFeed devuelve las fechas de salida exactas de todos los juegos como
+// calendario iCalendar para suscribirse; ?estado= filtra por estado.
+func (h *Handler) CalendarFeed(w http.ResponseWriter, r *http.Request) {
+	estado := r.URL.Query().Get("estado")
+	if estado != "" && !slices.Contains(validation.Estados, estado) {
+		http.Error(w, "invalid estado", http.StatusBadRequest)
+		return
+	}
+
+	games, err := h.Games.ListGames(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar los juegos para el calendario", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	cal := ical.Calendar{
+		ProdID: "-//tp-web//Lanzamientos//ES",
+		Name:   i18n.T(r.Context(), "calendar.feed_name"),
+	}
+	for _, g := range games {
+		// Las fechas parciales o por anunciar no son un día del calendario
+		if (estado != "" && g.Estado != estado) || g.FechaPrecision != validation.PrecisionDay {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", g.Fecha)
+		if err != nil {
+			slog.WarnContext(r.Context(), "Fecha de salida inválida, se omite del calendario", "id", g.ID, "fecha", g.Fecha)
+			continue
+		}
+		stamp := g.CreatedAt.Time
+		if !g.CreatedAt.Valid {
+			stamp = time.Now()
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         fmt.Sprintf("game-%d@%s", g.ID, r.Host),
+			Date:        date,
+			Summary:     g.Titulo,
+			Description: g.Descripcion,
+			URL:         fmt.Sprintf("%s://%s/games/%d", scheme, r.Host, g.ID),
+			Stamp:       stamp,
+		})
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="lanzamientos.ics"`)
+	if _, err := cal.WriteTo(w); err != nil {
+		slog.WarnContext(r.Context(), "Error al escribir el calendario", "err", err)
+	}
+}
@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"tp-web/db/dberrors"
+	datos "tp-web/db/sqlc"
+	"tp-web/i18n"
+	"tp-web/validation"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// Index muestra la lista de juegos junto al formulario de alta, ordenada por
+// título o, con ?sort=rating o ?sort=release, por puntaje promedio o por
+// fecha de salida. Con ?group=series la lista se agrupa por serie.
+func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
+	games, err := h.Games.ListGames(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error en la capa de datos al listar todos los juegos", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	slog.DebugContext(r.Context(), "Juegos recuperados", "total", len(games))
+	sortGames(games, r.URL.Query().Get("sort"))
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, games)
+		return
+	}
+
+	playing, err := h.Games.ListPlayingGames(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar los juegos en curso", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	view := views.ListView{Sort: r.URL.Query().Get("sort"), Grouped: r.URL.Query().Get("group") == "series"}
+	templ.Handler(views.IndexPage("Lista de Juegos", games, playing, view)).ServeHTTP(w, r)
+}
+
+// CreateGame crea un juego nuevo a partir del formulario o de un cuerpo JSON.
+func (h *Handler) CreateGame(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeInput(r, gameFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	// usar imagen por defecto para todos los juegos (no viene del form)
+	input.Imagen = defaultImage(input.Titulo)
+
+	release, errs := validation.ValidateGame(input)
+	if len(errs) > 0 {
+		renderValidationErrors(w, r, http.StatusUnprocessableEntity, input, errs)
+		return
+	}
+
+	// Antes de guardar, avisar si hay juegos con un título parecido (salvo que el usuario confirme)
+	if !input.ConfirmDuplicate {
+		similar, err := h.Games.ListSimilarGames(r.Context(), input.Titulo)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error al buscar juegos similares", "err", err)
+			writeDBError(w, r, err)
+			return
+		}
+		if len(similar) > 0 {
+			renderDuplicateWarning(w, r, input, similar)
+			return
+		}
+	}
+
+	// Portada del juego elegido en el catálogo; si no se puede descargar
+	// queda la imagen por defecto
+	var coverFile string
+	if input.MetadataID != "" && h.Metadata != nil {
+		imagen, file, err := h.saveCover(r.Context(), input.MetadataID)
+		if err != nil {
+			slog.WarnContext(r.Context(), "No se pudo descargar la portada", "catalogo", h.Metadata.Name(), "id_catalogo", input.MetadataID, "err", err)
+		} else {
+			input.Imagen, coverFile = imagen, file
+		}
+	}
+
+	// Juego, etiquetas y revisión inicial en una sola transacción
+	game, err := h.Service.CreateGame(r.Context(), datos.CreateGameParams{
+		Titulo:         input.Titulo,
+		Descripcion:    input.Descripcion,
+		Categoria:      input.Categoria,
+		Plataforma:     input.Plataforma,
+		Fecha:          release.Date,
+		FechaPrecision: release.Precision,
+		Estado:         input.Estado,
+		Imagen:         input.Imagen,
+	}, input.Tags)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al crear juego", "titulo", input.Titulo, "err", err)
+		// La portada recién descargada no quedó asociada a ningún juego
+		if coverFile != "" {
+			if err := os.Remove(coverFile); err != nil {
+				slog.WarnContext(r.Context(), "No se pudo borrar la portada", "archivo", coverFile, "err", err)
+			}
+		}
+		if dbErr := dberrors.Translate(err); dbErr.Field != "" && (dbErr.Status == http.StatusUnprocessableEntity || dbErr.Status == http.StatusConflict) {
+			renderValidationErrors(w, r, dbErr.Status, input, validation.Errors{dbErr.Field: dbErr.Key})
+			return
+		}
+		writeDBError(w, r, err)
+		return
+	}
+
+	if isHTMX(r) { // Si es una petición HTMX, solo se renderiza la lista
+		games, err := h.Games.ListGames(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error en la capa de datos al listar todos los juegos", "err", err)
+			writeDBError(w, r, err)
+			return
+		}
+
+		views.EntityList(games).Render(r.Context(), w)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusCreated, game)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// defaultImage es la imagen de un juego nuevo: img/<título>.jpg con el título
+// pasado a slug y recortado para que entre en la columna imagen. Si del
+// título no queda nada se usa la imagen por defecto.
+func defaultImage(titulo string) string {
+	slug := fileSlug(titulo, validation.MaxImagen-len("img/.jpg"))
+	if slug == "" {
+		return "img/default.png"
+	}
+	return "img/" + slug + ".jpg"
+}
+
+// fileSlug deja en s solo minúsculas sin acentos, números y guiones (uno solo
+// por cada tramo de otros caracteres), con a lo sumo max bytes, para usarlo
+// como nombre de archivo.
+func fileSlug(s string, max int) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range slugAccents.Replace(strings.ToLower(s)) {
+		if b.Len() >= max {
+			break
+		}
+		switch {
+		case (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'):
+			b.WriteRune(c)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+var slugAccents = strings.NewReplacer("á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n")
+
+// BulkGames aplica una acción (eliminar, cambiar estado o categoría, agregar
+// etiquetas) a los juegos marcados en la lista, todo en una transacción.
+// Para HTMX devuelve la lista actualizada con un resumen de lo modificado.
+func (h *Handler) BulkGames(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeInput(r, bulkFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Acción masiva inválida", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	if errs := validation.ValidateBulk(input); len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	result, err := h.Service.Bulk(r.Context(), input)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al aplicar acción masiva", "accion", input.Action, "juegos", len(input.IDs), "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Acción masiva aplicada", "accion", result.Action, "pedidos", result.Requested, "afectados", result.Affected)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, result)
+		return
+	}
+
+	if isHTMX(r) {
+		games, err := h.Games.ListGames(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error en la capa de datos al listar todos los juegos", "err", err)
+			writeDBError(w, r, err)
+			return
+		}
+
+		summary := i18n.T(r.Context(), "bulk.summary."+result.Action, result.Affected, result.Requested)
+		views.BulkResult(games, summary).Render(r.Context(), w)
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// ShowGame renderiza la página de detalle de un juego con su historial de
+// revisiones. En JSON devuelve el juego con sus etiquetas, serie, ediciones
+// y reseñas.
+func (h *Handler) ShowGame(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	game, err := h.Games.GetGame(r.Context(), id)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	tags, err := h.Games.ListGameTags(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar etiquetas del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	reviews, err := h.Games.ListGameReviews(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar reseñas del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	series, err := h.gameSeries(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener la serie del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"game":       game,
+			"tags":       tags,
+			"series":     series.Entry,
+			"editions":   series.Editions,
+			"avg_rating": views.AverageRating(reviews),
+			"reviews":    reviews,
+		})
+		return
+	}
+
+	play, err := h.playHistory(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener el estado de juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	purchases, err := h.Games.ListGamePurchases(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar compras del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	revisions, err := h.Games.ListGameRevisions(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar revisiones del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	prices, err := h.priceHistory(r.Context(), game.ID)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener precios del juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	templ.Handler(views.Layout(views.GameDetail(game, tags, series, play, reviews, purchases, prices, revisions))).ServeHTTP(w, r)
+}
+
+// DeleteGame elimina un juego. Para HTMX responde 200 con cuerpo vacío y
+// HTMX quita el elemento de la lista.
+func (h *Handler) DeleteGame(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.Games.DeleteGame(r.Context(), id); err != nil {
+		slog.ErrorContext(r.Context(), "Error al eliminar juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RevertGame vuelve un juego a los datos de una revisión anterior. La
+// reversión no reescribe el historial: se guarda como una revisión nueva.
+func (h *Handler) RevertGame(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+	rev, err := pathInt32(r, "rev")
+	if err != nil {
+		http.Error(w, "revisión inválida", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.Service.RevertGame(r.Context(), id, rev); err != nil {
+		slog.ErrorContext(r.Context(), "Error al revertir juego", "id", id, "revision", rev, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// sortGames ordena la lista según el parámetro sort. ListGames ya viene por
+// título; "rating" pone primero los de mejor promedio y al final los que no
+// tienen reseñas; "release" ordena por fecha de salida. Como las fechas
+// parciales se guardan con el último día del período, "T3 2026" queda
+// después de los juegos de septiembre de 2026 y los por anunciar al final.
+func sortGames(games []datos.ListGamesRow, sort string) {
+	switch sort {
+	case "rating":
+		slices.SortStableFunc(games, func(a, b datos.ListGamesRow) int {
+			if (a.ReviewCount == 0) != (b.ReviewCount == 0) {
+				if a.ReviewCount == 0 {
+					return 1
+				}
+				return -1
+			}
+			return cmp.Compare(b.AvgRating, a.AvgRating)
+		})
+	case "release":
+		slices.SortStableFunc(games, func(a, b datos.ListGamesRow) int {
+			return cmp.Or(
+				cmp.Compare(a.Fecha, b.Fecha),
+				cmp.Compare(slices.Index(validation.Precisions, a.FechaPrecision), slices.Index(validation.Precisions, b.FechaPrecision)),
+			)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+	"net/url"
+	"strconv"
+	"tp-web/i18n"
+	"tp-web/metadata"
+	"tp-web/metrics"
+	"tp-web/middleware"
+	"tp-web/repository"
+	"tp-web/service"
+)
+
+// Handler agrupa las dependencias que necesitan los handlers HTTP.
+type Handler struct {
+	Games repository.Store
+	// Service ejecuta las operaciones de varios pasos en una transacción
+	Service *service.Games
+	// ImageDir es la carpeta que se sirve en /img/
+	ImageDir string
+	// Metadata es el catálogo donde se buscan los datos de los juegos al
+	// darlos de alta; nil desactiva la búsqueda.
+	Metadata metadata.Provider
+	// UpcomingDays es cuántos días hacia adelante muestra /calendar en los
+	// próximos lanzamientos deseados.
+	UpcomingDays int
+	// DB es el pool de conexiones, para exponer su estado; puede ser nil
+	// (por ejemplo con el repositorio en memoria).
+	DB *sql.DB
+	// HTTPMetrics son las métricas de peticiones que se exponen en /metrics;
+	// puede ser nil.
+	HTTPMetrics *metrics.HTTP
+	// AdminAuth protege las rutas de administración y /debug/db. Si es nil
+	// (sin autenticación configurada) esas rutas no se registran.
+	AdminAuth middleware.Middleware
+}
+
+// New crea un Handler que usa games para acceder a los datos de juegos.
+func New(games repository.Store) *Handler {
+	return &Handler{Games: games, Service: service.NewGames(games), ImageDir: "img", UpcomingDays: 30}
+}
+
+// Routes registra todas las rutas de la aplicación en un mux nuevo.
+func (h *Handler) Routes() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /{$}", h.Index)
+	mux.HandleFunc("POST /games", h.CreateGame)
+	mux.HandleFunc("POST /games/bulk", h.BulkGames)
+	mux.HandleFunc("GET /games/{id}", h.ShowGame)
+	mux.HandleFunc("DELETE /games/{id}", h.DeleteGame)
+	mux.HandleFunc("POST /games/{id}/revisions/{rev}/revert", h.RevertGame)
+	mux.HandleFunc("GET /games/{id}/purchases/new", h.NewPurchase)
+	mux.HandleFunc("POST /games/{id}/purchases", h.CreatePurchase)
+	mux.HandleFunc("POST /games/{id}/progress", h.SetProgress)
+	mux.HandleFunc("POST /games/{id}/sessions", h.LogSession)
+	mux.HandleFunc("POST /games/{id}/sessions/start", h.StartSession)
+	mux.HandleFunc("POST /games/{id}/sessions/stop", h.StopSession)
+	mux.HandleFunc("POST /games/{id}/reviews", h.SaveReview)
+	mux.HandleFunc("POST /games/{id}/reviews/{review}/delete", h.DeleteReview)
+	mux.HandleFunc("POST /games/{id}/series", h.SetGameSeries)
+	mux.HandleFunc("POST /games/{id}/series/delete", h.DeleteGameSeries)
+	mux.HandleFunc("POST /games/{id}/editions", h.SaveEdition)
+	mux.HandleFunc("POST /games/{id}/editions/{edition}/delete", h.DeleteEdition)
+	mux.HandleFunc("POST /games/{id}/price-watch", h.SetPriceWatch)
+	mux.HandleFunc("POST /games/{id}/price-watch/delete", h.DeletePriceWatch)
+	mux.HandleFunc("POST /games/{id}/prices", h.RecordPrice)
+	mux.HandleFunc("GET /notifications", h.Notifications)
+	mux.HandleFunc("GET /notifications/count", h.NotificationCount)
+	mux.HandleFunc("POST /notifications/read", h.MarkNotificationsRead)
+	mux.HandleFunc("GET /series", h.ListSeries)
+	mux.HandleFunc("POST /series", h.CreateSeries)
+	mux.HandleFunc("GET /series/{id}", h.ShowSeries)
+	mux.HandleFunc("POST /series/{id}/delete", h.DeleteSeries)
+	mux.HandleFunc("GET /stats", h.Stats)
+	mux.HandleFunc("GET /metadata/search-box", h.MetadataSearchBox)
+	if h.Metadata != nil {
+		mux.HandleFunc("GET /metadata/search", h.SearchMetadata)
+		mux.HandleFunc("GET /metadata/games/{id}", h.MetadataGame)
+	}
+	mux.HandleFunc("GET /calendar", h.Calendar)
+	mux.HandleFunc("GET /calendar.ics", h.CalendarFeed)
+	mux.HandleFunc("GET /lang", h.SetLang)
+
+	// Monitoreo
+	mux.HandleFunc("GET /healthz", h.Healthz)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+	mux.HandleFunc("GET /metrics", h.Metrics)
+
+	// Administración y estado interno: solo con autenticación configurada, y
+	// piden usuario y contraseña también para leer
+	if h.AdminAuth != nil {
+		mux.Handle("GET /admin/log-level", h.AdminAuth(http.HandlerFunc(h.LogLevel)))
+		mux.Handle("PUT /admin/log-level", h.AdminAuth(http.HandlerFunc(h.SetLogLevel)))
+		if h.DB != nil {
+			mux.Handle("GET /debug/db", h.AdminAuth(http.HandlerFunc(h.DBStats)))
+		}
+	}
+
+	// Archivos estáticos de la carpeta de imágenes
+	mux.Handle("GET /img/", http.StripPrefix("/img/", http.FileServer(http.Dir(h.ImageDir))))
+
+	return mux
+}
+
+// SetLang cambia el idioma de la interfaz guardando la elección en una cookie.
+func (h *Handler) SetLang(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("l")
+	if !i18n.Supported(lang) {
+		http.Error(w, "idioma no soportado", http.StatusBadRequest)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     i18n.CookieName,
+		Value:    lang,
+		Path:     "/",
+		MaxAge:   365 * 24 * 60 * 60,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// Volver a la página desde la que se cambió el idioma, si es de este sitio
+	back := "/"
+	if ref, err := url.Parse(r.Referer()); err == nil && ref.Host == r.Host && ref.Path != "" {
+		back = ref.RequestURI()
+	}
+	http.Redirect(w, r, back, http.StatusSeeOther)
+}
+
+// pathInt32 lee un parámetro numérico de la ruta, por ejemplo el {id} de "/games/{id}".
+func pathInt32(r *http.Request, name string) (int32, error) {
+	n, err := strconv.ParseInt(r.PathValue(name), 10, 32)
+	return int32(n), err
+}
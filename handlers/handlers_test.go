@@ -0,0 +1,1215 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/handlers"
+	"tp-web/i18n"
+	"tp-web/logging"
+	"tp-web/metadata"
+	"tp-web/metrics"
+	"tp-web/middleware"
+	"tp-web/repository"
+	"tp-web/validation"
+)
+
+func newTestServer(t *testing.T) (http.Handler, *repository.Memory) {
+	t.Helper()
+	repo := repository.NewMemory()
+	return i18n.Middleware(handlers.New(repo).Routes()), repo
+}
+
+func seedGame(t *testing.T, repo *repository.Memory, titulo string) datos.CreateGameRow {
+	t.Helper()
+	game, err := repo.CreateGame(context.Background(), datos.CreateGameParams{
+		Titulo:         titulo,
+		Descripcion:    "Descripción de " + titulo,
+		Categoria:      "Accion",
+		Fecha:          time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC),
+		FechaPrecision: "day",
+		Estado:         "none",
+		Imagen:         "img/" + titulo + ".png",
+	})
+	if err != nil {
+		t.Fatalf("seed %q: %v", titulo, err)
+	}
+	return game
+}
+
+func gameForm(title string) url.Values {
+	return url.Values{
+		"title":        {title},
+		"description":  {"Juego de prueba"},
+		"category":     {"Accion"},
+		"release_date": {"2025-09-15"},
+		"state":        {"deseado"},
+	}
+}
+
+func postForm(h http.Handler, path string, form url.Values, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIndexListsGames(t *testing.T) {
+	h, repo := newTestServer(t)
+	seedGame(t, repo, "FIFA25")
+	seedGame(t, repo, "Battlefield 5")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"FIFA25", "Battlefield 5", "10 de septiembre de 2024", `id="createGameForm"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+	if strings.Index(body, "Battlefield 5") > strings.Index(body, "FIFA25") {
+		t.Errorf("games are not ordered by title")
+	}
+}
+
+func TestCreateGame(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    map[string]string
+		wantStatus int
+		wantBody   string
+	}{
+		{"form redirects to index", nil, http.StatusSeeOther, ""},
+		{"htmx renders list fragment", map[string]string{"HX-Request": "true"}, http.StatusOK, `id="gamesList"`},
+		{"json returns created game", map[string]string{"Accept": "application/json"}, http.StatusCreated, `"titulo":"Call of Duty"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, repo := newTestServer(t)
+
+			rec := postForm(h, "/games", gameForm("Call of Duty"), tt.headers)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body does not contain %q: %s", tt.wantBody, rec.Body)
+			}
+			if strings.HasPrefix(tt.wantBody, `id=`) && strings.Contains(rec.Body.String(), "<html") {
+				t.Errorf("htmx response should be a fragment, got a full page")
+			}
+
+			games, _ := repo.ListGames(context.Background())
+			if len(games) != 1 || games[0].Titulo != "Call of Duty" || games[0].Fecha != "2025-09-15" {
+				t.Fatalf("games = %+v", games)
+			}
+			revisions, _ := repo.ListGameRevisions(context.Background(), games[0].ID)
+			if len(revisions) != 1 || revisions[0].Revision != 1 {
+				t.Errorf("revisions = %+v, want initial revision", revisions)
+			}
+		})
+	}
+}
+
+func TestCreateGameDefaultImage(t *testing.T) {
+	h, repo := newTestServer(t)
+
+	titulo := "Pokémon / Edición " + strings.Repeat("Muy Larga ", 10)
+	if rec := postForm(h, "/games", gameForm(titulo), nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("long title: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	games, _ := repo.ListGames(context.Background())
+	if len(games) != 1 {
+		t.Fatalf("games = %+v", games)
+	}
+	imagen := games[0].Imagen
+	if len(imagen) > validation.MaxImagen || !strings.HasPrefix(imagen, "img/pokemon-edicion-muy-larga-") || strings.ContainsAny(imagen[len("img/"):], " /") {
+		t.Errorf("imagen = %q, want a bounded slug of the title", imagen)
+	}
+}
+
+func TestCreateGameValidationErrors(t *testing.T) {
+	h, repo := newTestServer(t)
+
+	form := gameForm(strings.Repeat("x", 151))
+	form.Set("state", "perdido")
+
+	rec := postForm(h, "/games", form, map[string]string{"HX-Request": "true"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	if got := rec.Header().Get("HX-Retarget"); got != "#gameFormSection" {
+		t.Errorf("HX-Retarget = %q", got)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"El título no puede superar los 150 caracteres.", "Seleccioná un estado válido.", `value="Juego de prueba"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+
+	rec = postForm(h, "/games", url.Values{}, map[string]string{"Accept": "application/json", "Accept-Language": "en"})
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("json status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	var payload struct {
+		Errors map[string]string `json:"errors"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Errors["title"] != "Title is required." || payload.Errors["release_date"] == "" {
+		t.Errorf("errors = %v", payload.Errors)
+	}
+
+	if games, _ := repo.ListGames(context.Background()); len(games) != 0 {
+		t.Errorf("invalid games were saved: %+v", games)
+	}
+}
+
+func TestPartialReleaseDates(t *testing.T) {
+	h, repo := newTestServer(t)
+
+	// Formulario: la fecha del <input type="date"> se lleva al fin del trimestre
+	form := gameForm("Hollow Knight: Silksong")
+	form.Set("release_date", "2026-08-15")
+	form.Set("release_precision", "quarter")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("quarter: status = %d; body: %s", rec.Code, rec.Body)
+	}
+	// Por anunciar no necesita fecha
+	form = gameForm("Elder Scrolls VI")
+	form.Set("release_date", "")
+	form.Set("release_precision", "tba")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("tba: status = %d; body: %s", rec.Code, rec.Body)
+	}
+
+	// JSON: la precisión se deduce del formato
+	for body, want := range map[string]string{
+		`{"title":"Hades II","description":"d","category":"Accion","state":"deseado","release_date":"2026-09"}`: `"fecha":"2026-09-30","fecha_precision":"month"`,
+		`{"title":"Tunic 2","description":"d","category":"Accion","state":"deseado","release_date":"2026"}`:     `"fecha":"2026-12-31","fecha_precision":"year"`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/games", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated || !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("POST %s: status = %d, body %s, want %s", body, rec.Code, rec.Body, want)
+		}
+	}
+
+	form = gameForm("Sin fecha")
+	form.Set("release_date", "")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "La fecha de salida es obligatoria.") {
+		t.Errorf("empty date: status = %d", rec.Code)
+	}
+	if games, _ := repo.ListGames(context.Background()); len(games) != 4 {
+		t.Errorf("games = %d, want 4", len(games))
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?sort=release", nil))
+	body := rec.Body.String()
+	var last int
+	for _, want := range []string{"septiembre de 2026", "T3 2026", "</strong> 2026</p>", "Por anunciar"} {
+		i := strings.Index(body, want)
+		if i < last {
+			t.Errorf("%q not found after the previous release (index %d)", want, i)
+		}
+		last = i
+	}
+}
+
+func TestMetadataLookup(t *testing.T) {
+	// Sin catálogo el formulario no muestra el buscador
+	h, _ := newTestServer(t)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metadata/search-box", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("search box without provider: status = %d", rec.Code)
+	}
+
+	repo := repository.NewMemory()
+	hnd := handlers.New(repo)
+	hnd.Metadata = metadata.Fake{}
+	hnd.ImageDir = t.TempDir()
+	h = i18n.Middleware(hnd.Routes())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metadata/search?q=wolver", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `hx-get="/metadata/games/marvels-wolverine"`) {
+		t.Errorf("search results: %s", body)
+	}
+
+	// Elegir un resultado completa el formulario y conserva lo ya cargado
+	req := httptest.NewRequest(http.MethodGet, "/metadata/games/marvels-wolverine?state=deseado&tags=sigilo", nil)
+	req.Header.Set("HX-Request", "true")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	body := rec.Body.String()
+	for _, want := range []string{`value="Marvel&#39;s Wolverine"`, `value="2026-09-30"`, `<option value="quarter" selected>`, `<option value="deseado" selected>`, `value="sigilo"`, `name="metadata_id" value="marvels-wolverine"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("prefilled form does not contain %q", want)
+		}
+	}
+
+	form := gameForm("Marvel's Wolverine")
+	form.Set("metadata_id", "marvels-wolverine")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: status = %d; body: %s", rec.Code, rec.Body)
+	}
+	games, _ := repo.ListGames(context.Background())
+	if len(games) != 1 || !strings.HasPrefix(games[0].Imagen, "img/cover-marvels-wolverine-") || !strings.HasSuffix(games[0].Imagen, ".png") {
+		t.Fatalf("games = %+v", games)
+	}
+	cover := filepath.Join(hnd.ImageDir, strings.TrimPrefix(games[0].Imagen, "img/"))
+	if _, err := os.Stat(cover); err != nil {
+		t.Errorf("cover was not saved: %v", err)
+	}
+
+	// Si el alta falla no queda la portada descargada, y una que ya estaba
+	// no se pisa ni se borra
+	os.WriteFile(cover, []byte("portada anterior"), 0o644)
+	for _, id := range []string{"hades-ii", "marvels-wolverine"} {
+		form.Set("metadata_id", id)
+		form.Set("confirm_duplicate", "true")
+		if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusConflict {
+			t.Fatalf("duplicate title with cover %s: status = %d", id, rec.Code)
+		}
+	}
+	if leftover, _ := filepath.Glob(filepath.Join(hnd.ImageDir, "cover-hades-ii-*")); len(leftover) > 0 {
+		t.Errorf("cover of the failed game was left behind: %v", leftover)
+	}
+	if data, _ := os.ReadFile(cover); string(data) != "portada anterior" {
+		t.Errorf("existing cover was overwritten or removed: %q", data)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metadata/games/nope", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("unknown game: status = %d", rec.Code)
+	}
+}
+
+// coverCatalog es un catálogo que tiene portada para cualquier id, con el id
+// como contenido.
+type coverCatalog struct{ metadata.Fake }
+
+func (coverCatalog) Cover(ctx context.Context, id string) (metadata.Cover, error) {
+	return metadata.Cover{Data: []byte(id), Ext: ".png"}, nil
+}
+
+func TestCoverFileNames(t *testing.T) {
+	repo := repository.NewMemory()
+	hnd := handlers.New(repo)
+	hnd.Metadata = coverCatalog{}
+	hnd.ImageDir = t.TempDir()
+	h := i18n.Middleware(hnd.Routes())
+
+	// Ids con el mismo slug, o sin ninguna letra que sirva, no comparten
+	// portada
+	ids := []string{"Zelda!", "zelda?", "ゼルダ", "ポケモン"}
+	seen := map[string]bool{}
+	for i, id := range ids {
+		form := gameForm("Juego " + itoa(int32(i)))
+		form.Set("metadata_id", id)
+		form.Set("confirm_duplicate", "true")
+		if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("create %q: status = %d", id, rec.Code)
+		}
+		games, _ := repo.ListGames(context.Background())
+		var imagen string
+		for _, g := range games {
+			if g.Titulo == "Juego "+itoa(int32(i)) {
+				imagen = g.Imagen
+			}
+		}
+		if seen[imagen] || strings.Contains(imagen, "cover-.") || strings.Contains(imagen, "cover--") {
+			t.Errorf("cover for %q = %q", id, imagen)
+		}
+		seen[imagen] = true
+		if data, _ := os.ReadFile(filepath.Join(hnd.ImageDir, strings.TrimPrefix(imagen, "img/"))); string(data) != id {
+			t.Errorf("cover for %q has the contents of %q", id, data)
+		}
+	}
+}
+
+func TestCreateGameDuplicates(t *testing.T) {
+	h, repo := newTestServer(t)
+	seedGame(t, repo, "FIFA25")
+
+	// Título parecido: se muestra la advertencia y no se guarda
+	rec := postForm(h, "/games", gameForm("FIFA 25"), map[string]string{"HX-Request": "true"})
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `name="confirm_duplicate"`) {
+		t.Fatalf("status = %d, want duplicate warning; body: %s", rec.Code, rec.Body)
+	}
+	if games, _ := repo.ListGames(context.Background()); len(games) != 1 {
+		t.Fatalf("game saved without confirmation")
+	}
+
+	// Confirmado por el usuario: se guarda
+	form := gameForm("FIFA 25")
+	form.Set("confirm_duplicate", "true")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("confirmed status = %d; body: %s", rec.Code, rec.Body)
+	}
+
+	// Mismo título normalizado: lo rechaza el índice único aunque se confirme
+	form = gameForm("fifa25")
+	form.Set("confirm_duplicate", "true")
+	rec = postForm(h, "/games", form, map[string]string{"Accept": "application/json"})
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("duplicate status = %d, want %d; body: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+
+	// En otra plataforma es otro juego; dos veces en la misma, no
+	form.Set("platform", "PS5")
+	if rec := postForm(h, "/games", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("other platform status = %d; body: %s", rec.Code, rec.Body)
+	}
+	form.Set("title", "FÍFA25")
+	rec = postForm(h, "/games", form, map[string]string{"Accept": "application/json"})
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), `"title":`) {
+		t.Fatalf("same platform status = %d, want %d; body: %s", rec.Code, http.StatusConflict, rec.Body)
+	}
+	games, _ := repo.ListGames(context.Background())
+	var platforms []string
+	for _, g := range games {
+		platforms = append(platforms, g.Plataforma)
+	}
+	if len(games) != 3 || !slices.Contains(platforms, "PS5") {
+		t.Fatalf("games = %+v", games)
+	}
+}
+
+func TestDeleteGame(t *testing.T) {
+	h, repo := newTestServer(t)
+	game := seedGame(t, repo, "FIFA25")
+	path := "/games/" + itoa(game.ID)
+
+	req := httptest.NewRequest(http.MethodDelete, path, nil)
+	req.Header.Set("HX-Request", "true")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("status = %d, body = %q; want 200 with empty body", rec.Code, rec.Body)
+	}
+	if _, err := repo.GetGame(context.Background(), game.ID); err == nil {
+		t.Fatalf("game still exists after delete")
+	}
+
+	// Borrar de nuevo: 404 con el mensaje en #flash
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotFound || rec.Header().Get("HX-Retarget") != "#flash" {
+		t.Errorf("second delete status = %d, HX-Retarget = %q", rec.Code, rec.Header().Get("HX-Retarget"))
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/games/abc", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid id status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBulkGames(t *testing.T) {
+	h, repo := newTestServer(t)
+	fifa := seedGame(t, repo, "FIFA25")
+	pes := seedGame(t, repo, "PES")
+	seedGame(t, repo, "Battlefield 5")
+	htmx := map[string]string{"HX-Request": "true"}
+
+	form := url.Values{"ids": {itoa(fifa.ID), itoa(pes.ID)}, "action": {"set_category"}, "category": {"Deportes"}}
+	rec := postForm(h, "/games/bulk", form, htmx)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`id="gamesList"`, "Se cambió la categoría de 2 de 2 juegos seleccionados.", "Battlefield 5"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+	if got, _ := repo.GetGame(context.Background(), pes.ID); got.Categoria != "Deportes" {
+		t.Errorf("categoria = %q", got.Categoria)
+	}
+
+	// Sin juegos marcados: 422 con el mensaje en #flash
+	rec = postForm(h, "/games/bulk", url.Values{"action": {"delete"}}, htmx)
+	if rec.Code != http.StatusUnprocessableEntity || rec.Header().Get("HX-Retarget") != "#flash" {
+		t.Errorf("no ids: status = %d, HX-Retarget = %q", rec.Code, rec.Header().Get("HX-Retarget"))
+	}
+
+	// Pasar a comprado pide la compra de cada juego: no se ofrece en masa
+	rec = postForm(h, "/games/bulk", url.Values{"ids": {itoa(pes.ID)}, "action": {"set_state"}, "state": {"comprado"}}, htmx)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "registrá la compra") {
+		t.Errorf("comprado: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if got, _ := repo.GetGame(context.Background(), pes.ID); got.Estado == "comprado" {
+		t.Error("bulk set_state changed the game to comprado")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/games/bulk", strings.NewReader(`{"action":"delete","ids":[`+itoa(fifa.ID)+`,`+itoa(fifa.ID)+`,999]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var result struct {
+		Action              string
+		Requested, Affected int
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&result); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("json: status = %d, err = %v", rec.Code, err)
+	}
+	if result.Action != "delete" || result.Requested != 2 || result.Affected != 1 {
+		t.Errorf("result = %+v, want 1 of 2 deleted", result)
+	}
+}
+
+func TestStats(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	seedGame(t, repo, "FIFA25")
+	old := seedGame(t, repo, "Battlefield 5")
+	repo.UpdateGame(ctx, datos.UpdateGameParams{ID: old.ID, Titulo: "Battlefield 5", Descripcion: "d", Categoria: "Shooter", Fecha: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), FechaPrecision: "day", Estado: "comprado", Imagen: "i"})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`id="chartEstado"`, `id="chartMonth"`, "<svg", "Shooter: 1", "Comprado: 1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var stats struct {
+		Total  int
+		ByYear []struct{ Label string } `json:"by_year"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&stats); err != nil {
+		t.Fatal(err)
+	}
+	// 2021 a 2024, con los años sin juegos en cero
+	if stats.Total != 2 || len(stats.ByYear) != 4 || stats.ByYear[1].Label != "2022" {
+		t.Errorf("stats = %+v", stats)
+	}
+
+	// Un año fuera de lugar no llena el gráfico de años vacíos
+	tba := seedGame(t, repo, "Half-Life 3")
+	repo.UpdateGame(ctx, datos.UpdateGameParams{ID: tba.ID, Titulo: "Half-Life 3", Descripcion: "d", Categoria: "Shooter", Fecha: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC), FechaPrecision: "year", Estado: "deseado", Imagen: "i"})
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	stats.ByYear = nil
+	if err := json.NewDecoder(rec.Body).Decode(&stats); err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.ByYear) != 5 || stats.ByYear[4].Label != "9999" {
+		t.Errorf("by_year = %+v", stats.ByYear)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	seedGame(t, repo, "FIFA25")
+	soon := seedGame(t, repo, "Hades II")
+	y, m, d := time.Now().Date()
+	fecha := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 5)
+	repo.UpdateGame(ctx, datos.UpdateGameParams{ID: soon.ID, Titulo: "Hades II", Descripcion: "Roguelike; con dioses", Categoria: "Accion", Fecha: fecha, FechaPrecision: "day", Estado: "deseado", Imagen: "i"})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar?month=2024-09", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`id="releaseCalendar"`, `<time datetime="2024-09-10">10</time> <ul><li><a href="/games/1">FIFA25</a>`, `href="/calendar?month=2024-10"`, "Hades II"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("body does not contain %q", want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar?days=3", nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var cal struct {
+		Upcoming []datos.ListReleasesBetweenRow
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&cal); err != nil {
+		t.Fatal(err)
+	}
+	if len(cal.Upcoming) != 0 {
+		t.Errorf("upcoming in 3 days = %+v, want none", cal.Upcoming)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar?month=2024-13", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid month status = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/calendar.ics?estado=deseado", nil))
+	if ct := rec.Header().Get("Content-Type"); rec.Code != http.StatusOK || !strings.HasPrefix(ct, "text/calendar") {
+		t.Fatalf("feed status = %d, content type %q", rec.Code, ct)
+	}
+	body = rec.Body.String()
+	for _, want := range []string{"SUMMARY:Hades II\r\n", "DTSTART;VALUE=DATE:" + fecha.Format("20060102") + "\r\n", `DESCRIPTION:Roguelike\; con dioses`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("feed does not contain %q", want)
+		}
+	}
+	if strings.Contains(body, "FIFA25") {
+		t.Errorf("feed filtered by estado contains a game that is not wanted")
+	}
+}
+
+func TestPurchases(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	game := seedGame(t, repo, "FIFA25")
+	path := "/games/" + itoa(game.ID) + "/purchases"
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path+"/new", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `id="purchaseForm"`) {
+		t.Fatalf("new purchase: status = %d", rec.Code)
+	}
+
+	form := url.Values{"price": {"abc"}, "currency": {"usd"}, "store": {""}, "purchase_date": {"2025-03-01"}, "format": {"physical"}}
+	rec = postForm(h, path, form, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "La tienda es obligatoria.") {
+		t.Fatalf("invalid purchase: status = %d", rec.Code)
+	}
+
+	form.Set("price", "1234,5")
+	form.Set("store", "Steam")
+	if rec := postForm(h, path, form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if got, _ := repo.GetGame(ctx, game.ID); got.Estado != "comprado" {
+		t.Errorf("estado = %q, want comprado", got.Estado)
+	}
+	purchases, _ := repo.ListGamePurchases(ctx, game.ID)
+	if len(purchases) != 1 || purchases[0].PriceCents != 123450 || purchases[0].Currency != "USD" {
+		t.Fatalf("purchases = %+v", purchases)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/"+itoa(game.ID), nil))
+	if body := rec.Body.String(); !strings.Contains(body, "1.234,50 USD") || !strings.Contains(body, "Steam") {
+		t.Errorf("detail page does not show the purchase")
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `id="spendCategoria"`) || !strings.Contains(body, "marzo 2025") {
+		t.Errorf("stats do not show the spending")
+	}
+
+	rec = postForm(h, "/games/999/purchases", form, map[string]string{"Accept": "application/json"})
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("purchase for a missing game: status = %d", rec.Code)
+	}
+}
+
+func TestPriceWatch(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	game := seedGame(t, repo, "Hades")
+	path := "/games/" + itoa(game.ID)
+
+	rec := postForm(h, path+"/price-watch", url.Values{"price": {"15"}, "currency": {"usd"}}, nil)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "juego deseado") {
+		t.Fatalf("watch on a game not wanted: status = %d", rec.Code)
+	}
+
+	if _, err := repo.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: game.ID, Estado: "deseado"}); err != nil {
+		t.Fatal(err)
+	}
+	if rec := postForm(h, path+"/price-watch", url.Values{"price": {"15"}, "currency": {"usd"}}, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if watch, err := repo.GetPriceWatch(ctx, game.ID); err != nil || watch.TargetCents != 1500 || watch.Currency != "USD" {
+		t.Fatalf("watch = %+v, %v", watch, err)
+	}
+
+	rec = postForm(h, path+"/prices", url.Values{"price": {"x"}, "currency": {"USD"}}, map[string]string{"HX-Request": "true"})
+	if rec.Code != http.StatusUnprocessableEntity || rec.Header().Get("HX-Retarget") != "#flash" {
+		t.Errorf("invalid price: status = %d", rec.Code)
+	}
+	for _, price := range []string{"19,99", "14,99"} {
+		if rec := postForm(h, path+"/prices", url.Values{"price": {price}, "currency": {"USD"}}, nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if body := rec.Body.String(); !strings.Contains(body, `id="priceChart"`) || !strings.Contains(body, "15,00 USD") {
+		t.Errorf("detail page does not show the price history")
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/notifications/count", nil))
+	if !strings.Contains(rec.Body.String(), ">1<") {
+		t.Errorf("count = %q, want 1 unread", rec.Body)
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/notifications", nil))
+	if body := rec.Body.String(); !strings.Contains(body, "Hades bajó a 14,99 USD") {
+		t.Errorf("notifications page does not show the alert")
+	}
+	if rec := postForm(h, "/notifications/read", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("mark read: status = %d", rec.Code)
+	}
+	if count, _ := repo.CountUnreadNotifications(ctx); count != 0 {
+		t.Errorf("unread = %d after marking as read", count)
+	}
+
+	if rec := postForm(h, path+"/price-watch/delete", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("delete watch: status = %d", rec.Code)
+	}
+	if _, err := repo.GetPriceWatch(ctx, game.ID); err == nil {
+		t.Errorf("watch still exists")
+	}
+}
+
+func TestPlayTracking(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	game := seedGame(t, repo, "Hades")
+	path := "/games/" + itoa(game.ID)
+
+	form := url.Values{"started_at": {"2025-03-01T20:00"}, "ended_at": {"2025-03-01T19:00"}}
+	rec := postForm(h, path+"/sessions", form, map[string]string{"HX-Request": "true"})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "antes de empezar") {
+		t.Fatalf("invalid session: status = %d", rec.Code)
+	}
+	form = url.Values{"started_at": {"2025-03-01T20:00"}, "hours": {"2,5"}, "notes": {"Primera corrida"}}
+	if rec := postForm(h, path+"/sessions", form, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+	if rec := postForm(h, path+"/sessions/start", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("start: status = %d", rec.Code)
+	}
+	if rec := postForm(h, path+"/sessions/start", nil, map[string]string{"Accept": "application/json"}); rec.Code != http.StatusConflict {
+		t.Errorf("second start: status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if body := rec.Body.String(); !strings.Contains(body, `id="playingNow"`) || !strings.Contains(body, "2,5 h") {
+		t.Errorf("index does not show the game being played")
+	}
+
+	if rec := postForm(h, path+"/sessions/stop", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("stop: status = %d", rec.Code)
+	}
+	if sessions, _ := repo.ListPlaySessions(ctx, game.ID); len(sessions) != 2 || !sessions[0].EndedAt.Valid {
+		t.Errorf("sessions = %+v", sessions)
+	}
+
+	// Desde JSON las horas son un número
+	req := httptest.NewRequest(http.MethodPost, path+"/sessions", strings.NewReader(`{"started_at":"2025-03-02T20:00:00Z","hours":1.5}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var session struct {
+		StartedAt time.Time                `json:"started_at"`
+		EndedAt   struct{ Time time.Time } `json:"ended_at"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&session); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("json session: status = %d, err = %v", rec.Code, err)
+	}
+	if d := session.EndedAt.Time.Sub(session.StartedAt); d != 90*time.Minute {
+		t.Errorf("json session lasted %v, want 1h30m", d)
+	}
+
+	// Las horas del formulario están en la zona del navegador
+	form = url.Values{"started_at": {"2025-03-03T20:00"}, "hours": {"1"}, "tz": {"America/Argentina/Buenos_Aires"}}
+	rec = postForm(h, path+"/sessions", form, map[string]string{"Accept": "application/json"})
+	if err := json.NewDecoder(rec.Body).Decode(&session); err != nil || rec.Code != http.StatusCreated {
+		t.Fatalf("session with tz: status = %d, err = %v", rec.Code, err)
+	}
+	if want := time.Date(2025, 3, 3, 23, 0, 0, 0, time.UTC); !session.StartedAt.Equal(want) {
+		t.Errorf("started_at = %v, want %v", session.StartedAt, want)
+	}
+	form.Set("tz", "Mars/Olympus_Mons")
+	if rec := postForm(h, path+"/sessions", form, map[string]string{"HX-Request": "true"}); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("unknown tz: status = %d", rec.Code)
+	}
+
+	rec = postForm(h, path+"/progress", url.Values{"play_status": {"playing"}, "completed_on": {"2025-04-02"}}, nil)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("completion date while playing: status = %d", rec.Code)
+	}
+	if rec := postForm(h, path+"/progress", url.Values{"play_status": {"finished"}, "completed_on": {"2025-04-02"}}, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	if body := rec.Body.String(); !strings.Contains(body, "terminado el 2 de abril de 2025") || !strings.Contains(body, "Primera corrida") {
+		t.Errorf("detail page does not show the play progress")
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if strings.Contains(rec.Body.String(), `id="playingNow"`) {
+		t.Errorf("a finished game is still listed as being played")
+	}
+}
+
+func TestReviews(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	hades := seedGame(t, repo, "Hades")
+	celeste := seedGame(t, repo, "Celeste")
+	seedGame(t, repo, "Antichamber")
+	path := "/games/" + itoa(hades.ID)
+
+	rec := postForm(h, path+"/reviews", url.Values{"reviewer": {"ana"}, "rating": {"11"}}, map[string]string{"HX-Request": "true"})
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "del 1 al 10") {
+		t.Fatalf("invalid rating: status = %d", rec.Code)
+	}
+	for _, review := range []struct {
+		game     int32
+		reviewer string
+		rating   string
+	}{
+		{hades.ID, "ana", "7"},
+		{hades.ID, "ana", "9"}, // reemplaza la anterior
+		{hades.ID, "beto", "8"},
+		{celeste.ID, "ana", "10"},
+	} {
+		form := url.Values{"reviewer": {review.reviewer}, "rating": {review.rating}, "body": {"**Excelente** <script>alert(1)</script>"}}
+		if rec := postForm(h, "/games/"+itoa(review.game)+"/reviews", form, nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body)
+		}
+	}
+
+	// Desde JSON el puntaje es un número
+	req := httptest.NewRequest(http.MethodPost, "/games/"+itoa(celeste.ID)+"/reviews", strings.NewReader(`{"reviewer":"beto","rating":8,"body":"Difícil"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var saved struct {
+		Rating int32 `json:"rating"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&saved); err != nil || saved.Rating != 8 {
+		t.Fatalf("json review: status = %d, rating = %d, err = %v", rec.Code, saved.Rating, err)
+	}
+	if rec := postForm(h, path+"/reviews", url.Values{"reviewer": {"ana"}, "rating": {"diez"}}, map[string]string{"HX-Request": "true"}); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("non-numeric rating: status = %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, "<strong>Excelente</strong>") || !strings.Contains(body, "8,5 / 10 (2 reseñas)") {
+		t.Errorf("detail page does not show the reviews")
+	}
+	if strings.Contains(body, "<script>alert") {
+		t.Errorf("review HTML was not escaped")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var detail struct {
+		AvgRating float64 `json:"avg_rating"`
+		Reviews   []struct {
+			Reviewer string `json:"reviewer"`
+			Rating   int32  `json:"rating"`
+		} `json:"reviews"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &detail); err != nil || detail.AvgRating != 8.5 || len(detail.Reviews) != 2 {
+		t.Errorf("json detail = %+v, %v", detail, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?sort=rating", nil))
+	body = rec.Body.String()
+	if !(strings.Index(body, ">Celeste<") < strings.Index(body, ">Hades<") && strings.Index(body, ">Hades<") < strings.Index(body, ">Antichamber<")) {
+		t.Errorf("games are not sorted by rating, unrated last")
+	}
+	if !strings.Contains(body, "Sin puntaje") {
+		t.Errorf("list does not mark unrated games")
+	}
+
+	reviews, _ := repo.ListGameReviews(ctx, hades.ID)
+	if rec := postForm(h, path+"/reviews/"+itoa(reviews[0].ID)+"/delete", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("delete: status = %d", rec.Code)
+	}
+	if reviews, _ := repo.ListGameReviews(ctx, hades.ID); len(reviews) != 1 {
+		t.Errorf("reviews = %d after deleting one, want 1", len(reviews))
+	}
+}
+
+func TestSeries(t *testing.T) {
+	h, repo := newTestServer(t)
+	ctx := context.Background()
+	fifa25 := seedGame(t, repo, "FIFA25")
+	fifa26 := seedGame(t, repo, "FIFA26")
+	seedGame(t, repo, "Celeste")
+	repo.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: fifa25.ID, Estado: "comprado"})
+
+	if rec := postForm(h, "/series", url.Values{"name": {" "}}, map[string]string{"HX-Request": "true"}); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("empty name: status = %d", rec.Code)
+	}
+	rec := postForm(h, "/series", url.Values{"name": {"FIFA"}}, nil)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("create: status = %d, body = %s", rec.Code, rec.Body)
+	}
+	seriesPath := rec.Header().Get("Location")
+	if rec := postForm(h, "/series", url.Values{"name": {"FIFA"}}, nil); rec.Code != http.StatusConflict {
+		t.Errorf("duplicate name: status = %d, want 409", rec.Code)
+	}
+	series, _ := repo.ListSeries(ctx)
+	seriesID := itoa(series[0].ID)
+
+	if rec := postForm(h, "/games/"+itoa(fifa26.ID)+"/series", url.Values{"series_id": {seriesID}, "position": {"0"}}, nil); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("position 0: status = %d", rec.Code)
+	}
+	for _, entry := range []struct {
+		game     int32
+		position string
+	}{{fifa26.ID, "2"}, {fifa25.ID, "1"}} {
+		form := url.Values{"series_id": {seriesID}, "position": {entry.position}}
+		if rec := postForm(h, "/games/"+itoa(entry.game)+"/series", form, nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("set series: status = %d, body = %s", rec.Code, rec.Body)
+		}
+	}
+	// Desde JSON la serie y la posición son números
+	req := httptest.NewRequest(http.MethodPost, "/games/"+itoa(fifa26.ID)+"/series", strings.NewReader(`{"series_id":`+seriesID+`,"position":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var entry struct {
+		SeriesID int32 `json:"series_id"`
+		Position int32 `json:"position"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&entry); err != nil || entry.SeriesID != series[0].ID || entry.Position != 2 {
+		t.Fatalf("json series entry: status = %d, entry = %+v, err = %v", rec.Code, entry, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, seriesPath, nil))
+	body := rec.Body.String()
+	if !strings.Contains(body, "1 de 2") || strings.Index(body, ">FIFA25<") > strings.Index(body, ">FIFA26<") {
+		t.Errorf("series page does not list the entries in order with progress:\n%s", body)
+	}
+
+	// Tener una edición cuenta como tener la entrega
+	if rec := postForm(h, "/games/"+itoa(fifa26.ID)+"/editions", url.Values{"edition": {"platinum"}}, nil); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("invalid edition: status = %d", rec.Code)
+	}
+	for _, form := range []url.Values{{"edition": {"deluxe"}}, {"edition": {"standard"}}, {"edition": {"deluxe"}, "owned": {"true"}}} {
+		if rec := postForm(h, "/games/"+itoa(fifa26.ID)+"/editions", form, nil); rec.Code != http.StatusSeeOther {
+			t.Fatalf("save edition: status = %d, body = %s", rec.Code, rec.Body)
+		}
+	}
+	req = httptest.NewRequest(http.MethodGet, seriesPath, nil)
+	req.Header.Set("Accept", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	var page struct {
+		Owned int `json:"owned"`
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil || page.Owned != 2 || page.Total != 2 {
+		t.Errorf("series json = %+v, %v", page, err)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/"+itoa(fifa26.ID), nil))
+	body = rec.Body.String()
+	if !strings.Contains(body, "(entrega #2)") || strings.Index(body, "Estándar") > strings.Index(body, "Deluxe") {
+		t.Errorf("detail page does not show the series and editions in order")
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?group=series", nil))
+	body = rec.Body.String()
+	if !(strings.Index(body, ">FIFA</a></h3>") < strings.Index(body, ">FIFA25<") &&
+		strings.Index(body, ">FIFA25<") < strings.Index(body, ">FIFA26<") &&
+		strings.Index(body, ">FIFA26<") < strings.Index(body, "Sin serie") &&
+		strings.Index(body, "Sin serie") < strings.Index(body, ">Celeste<")) {
+		t.Errorf("list is not grouped by series")
+	}
+
+	// Los enlaces de orden conservan la agrupación, y el de agrupar el orden
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?group=series&sort=rating", nil))
+	body = rec.Body.String()
+	for _, want := range []string{`href="/?group=series&amp;sort=title"`, `href="/?group=series&amp;sort=rating" aria-current="true"`, `href="/?sort=rating" aria-current="true"`} {
+		if !strings.Contains(body, want) {
+			t.Errorf("list links do not contain %q", want)
+		}
+	}
+
+	if rec := postForm(h, "/games/"+itoa(fifa25.ID)+"/series/delete", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("remove from series: status = %d", rec.Code)
+	}
+	if rec := postForm(h, seriesPath+"/delete", nil, nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("delete series: status = %d", rec.Code)
+	}
+	if _, err := repo.GetGameSeries(ctx, fifa26.ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("game still in a deleted series: %v", err)
+	}
+}
+
+func TestShowAndRevertGame(t *testing.T) {
+	h, repo := newTestServer(t)
+	if rec := postForm(h, "/games", gameForm("Call of Duty"), nil); rec.Code != http.StatusSeeOther {
+		t.Fatalf("create status = %d", rec.Code)
+	}
+	games, _ := repo.ListGames(context.Background())
+	id := games[0].ID
+
+	ctx := context.Background()
+	changed := datos.UpdateGameParams{ID: id, Titulo: "Call of Duty 2", Descripcion: "Otra", Categoria: "Accion", Fecha: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), FechaPrecision: "day", Estado: "comprado", Imagen: "img/cod2.png"}
+	if _, err := repo.UpdateGame(ctx, changed); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.CreateGameRevision(ctx, datos.CreateGameRevisionParams{GameID: id, Titulo: changed.Titulo, Descripcion: changed.Descripcion, Categoria: changed.Categoria, Fecha: changed.Fecha, FechaPrecision: changed.FechaPrecision, Estado: changed.Estado, Imagen: changed.Imagen}); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := postForm(h, "/games/"+itoa(id)+"/revisions/1/revert", nil, nil)
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("revert status = %d; body: %s", rec.Code, rec.Body)
+	}
+
+	game, _ := repo.GetGame(ctx, id)
+	if game.Titulo != "Call of Duty" || game.Fecha != "2025-09-15" {
+		t.Errorf("game after revert = %+v", game)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/games/"+itoa(id), nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("detail status = %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{"Revisión 3", "Revertido a la revisión 1", "Revisión actual", "/revisions/2/revert"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("detail does not contain %q", want)
+		}
+	}
+
+	rec = postForm(h, "/games/"+itoa(id)+"/revisions/9/revert", nil, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("missing revision status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func itoa(id int32) string {
+	return strconv.Itoa(int(id))
+}
+
+func TestHealthAndMetrics(t *testing.T) {
+	repo := repository.NewMemory()
+	h := handlers.New(repo)
+	h.HTTPMetrics = metrics.NewHTTP()
+	srv := i18n.Middleware(h.HTTPMetrics.Middleware(h.Routes()))
+	seedGame(t, repo, "FIFA25")
+	seedGame(t, repo, "Battlefield 5")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if rec := get(path); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"ok"`) {
+			t.Errorf("%s = %d %s", path, rec.Code, rec.Body)
+		}
+	}
+	get("/games/1")
+	get("/games/2")
+	get("/no-existe")
+	for _, m := range []string{"FOOBAR1", "FOOBAR2"} {
+		srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(m, "/x", nil))
+	}
+
+	rec := get("/metrics")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("metrics = %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/games/{id}",status="200"} 2`,
+		`http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`http_requests_total{method="OTHER",route="unmatched",status="404"} 2`,
+		`http_request_duration_seconds_count{method="GET",route="/healthz"} 1`,
+		`http_request_duration_seconds_bucket{method="GET",route="/games/{id}",le="+Inf"} 2`,
+		`games{estado="none"} 2`,
+		`games{estado="comprado"} 0`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestStructuredLogging(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, "json"))
+	logging.Level.Set(slog.LevelInfo)
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		logging.Level.Set(slog.LevelInfo)
+	})
+
+	hnd := handlers.New(repository.NewMemory())
+	hnd.AdminAuth = middleware.RequireBasicAuth("admin", "s3cret")
+	srv := middleware.Chain(i18n.Middleware(hnd.Routes()), middleware.RequestID, middleware.Logging)
+
+	req := httptest.NewRequest(http.MethodDelete, "/games/99", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+	req.Header.Set("HX-Request", "true")
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("log line is not JSON: %q", line)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 2 {
+		t.Fatalf("records = %v, want DB error and access log", records)
+	}
+	if records[0]["msg"] != "Error al eliminar juego" || records[0]["request_id"] != "req-123" {
+		t.Errorf("DB error log = %v", records[0])
+	}
+	access := records[1]
+	if access["request_id"] != "req-123" || access["method"] != "DELETE" || access["status"] != float64(404) || access["htmx"] != true {
+		t.Errorf("access log = %v", access)
+	}
+
+	// Un id inválido del cliente se reemplaza por uno nuevo
+	for _, id := range []string{"a b\nfalso=1", strings.Repeat("x", 65)} {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set(middleware.RequestIDHeader, id)
+		rec := httptest.NewRecorder()
+		srv.ServeHTTP(rec, req)
+		if got := rec.Header().Get(middleware.RequestIDHeader); got == id || got == "" {
+			t.Errorf("request id %q was echoed as %q", id, got)
+		}
+	}
+
+	// Cambiar el nivel en tiempo de ejecución
+	rec := httptest.NewRecorder()
+	put := httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"warn"}`))
+	put.Header.Set("Content-Type", "application/json")
+	srv.ServeHTTP(rec, put)
+	if rec.Code != http.StatusUnauthorized || logging.Level.Level() != slog.LevelInfo {
+		t.Fatalf("set level without credentials = %d, level = %v", rec.Code, logging.Level.Level())
+	}
+	rec = httptest.NewRecorder()
+	put = httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level":"warn"}`))
+	put.Header.Set("Content-Type", "application/json")
+	put.SetBasicAuth("admin", "s3cret")
+	srv.ServeHTTP(rec, put)
+	if rec.Code != http.StatusOK || logging.Level.Level() != slog.LevelWarn {
+		t.Fatalf("set level = %d %s, level = %v", rec.Code, rec.Body, logging.Level.Level())
+	}
+	buf.Reset()
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if buf.Len() != 0 {
+		t.Errorf("info access log written at warn level: %s", buf.String())
+	}
+
+	rec = httptest.NewRecorder()
+	put = httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader("level=verbose"))
+	put.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	put.SetBasicAuth("admin", "s3cret")
+	srv.ServeHTTP(rec, put)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid level status = %d", rec.Code)
+	}
+}
+
+func TestAdminRoutesNeedAuth(t *testing.T) {
+	// Sin autenticación configurada las rutas de administración no existen
+	h, _ := newTestServer(t)
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, "/admin/log-level", strings.NewReader(`{"level":"debug"}`)))
+		if rec.Code != http.StatusNotFound && rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s /admin/log-level without auth configured = %d", method, rec.Code)
+		}
+	}
+
+	// sql.Open no se conecta; alcanza para leer las estadísticas del pool
+	db, err := sql.Open("postgres", "host=localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	hnd := handlers.New(repository.NewMemory())
+	hnd.DB = db
+	rec := httptest.NewRecorder()
+	hnd.Routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/db", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /debug/db without auth configured = %d, want 404", rec.Code)
+	}
+
+	hnd.AdminAuth = middleware.RequireBasicAuth("admin", "s3cret")
+	h = hnd.Routes()
+	for _, path := range []string{"/admin/log-level", "/debug/db"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s without credentials = %d, want 401", path, rec.Code)
+		}
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.SetBasicAuth("admin", "s3cret")
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s with credentials = %d, want 200", path, rec.Code)
+		}
+	}
+}
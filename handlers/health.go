@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+	db_connect "tp-web/db"
+	"tp-web/metrics"
+	"tp-web/validation"
+)
+
+// readyTimeout limita cuánto espera /readyz a la base de datos.
+const readyTimeout = 2 * time.Second
+
+// Healthz responde siempre 200 mientras el proceso esté vivo.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz responde 200 si la base de datos responde y su esquema está al día;
+// si no, 503 con el motivo.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if h.DB != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+		defer cancel()
+		if err := db_connect.Ready(ctx, h.DB); err != nil {
+			slog.WarnContext(r.Context(), "Servicio no listo", "err", err)
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "error": err.Error()})
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// DBStats devuelve en JSON el estado del pool de conexiones a la base.
+func (h *Handler) DBStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, db_connect.Stats(h.DB))
+}
+
+// Metrics expone en formato Prometheus las métricas HTTP, las del pool de
+// conexiones y la cantidad de juegos por estado.
+func (h *Handler) Metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metrics.ContentType)
+	mw := metrics.NewWriter(w)
+
+	if h.HTTPMetrics != nil {
+		h.HTTPMetrics.Write(mw)
+	}
+
+	if h.DB != nil {
+		s := h.DB.Stats()
+		gauges := []struct {
+			name, help string
+			value      int
+		}{
+			{"db_max_open_connections", "Máximo de conexiones abiertas permitido.", s.MaxOpenConnections},
+			{"db_open_connections", "Conexiones abiertas, en uso o inactivas.", s.OpenConnections},
+			{"db_in_use_connections", "Conexiones en uso.", s.InUse},
+			{"db_idle_connections", "Conexiones inactivas.", s.Idle},
+		}
+		for _, g := range gauges {
+			mw.Header(g.name, "gauge", g.help)
+			mw.Sample(g.name, float64(g.value))
+		}
+		counters := []struct {
+			name, help string
+			value      float64
+		}{
+			{"db_wait_count_total", "Veces que se esperó una conexión libre.", float64(s.WaitCount)},
+			{"db_wait_duration_seconds_total", "Tiempo total esperando conexiones libres.", s.WaitDuration.Seconds()},
+			{"db_max_idle_closed_total", "Conexiones cerradas por db.max_idle_conns.", float64(s.MaxIdleClosed)},
+			{"db_max_idle_time_closed_total", "Conexiones cerradas por db.conn_max_idle_time.", float64(s.MaxIdleTimeClosed)},
+			{"db_max_lifetime_closed_total", "Conexiones cerradas por db.conn_max_lifetime.", float64(s.MaxLifetimeClosed)},
+		}
+		for _, c := range counters {
+			mw.Header(c.name, "counter", c.help)
+			mw.Sample(c.name, c.value)
+		}
+	}
+
+	counts, err := h.Games.CountGamesByEstado(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al contar juegos por estado para /metrics", "err", err)
+	} else {
+		totals := map[string]int64{}
+		for _, c := range counts {
+			totals[c.Estado] = c.Total
+		}
+		mw.Header("games", "gauge", "Juegos cargados por estado.")
+		for _, estado := range validation.Estados {
+			mw.Sample("games", float64(totals[estado]), "estado", estado)
+		}
+	}
+
+	if err := mw.Err(); err != nil {
+		slog.WarnContext(r.Context(), "Error al escribir /metrics", "err", err)
+	}
+}
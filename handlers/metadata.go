@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"tp-web/metadata"
+	"tp-web/validation"
+	views "tp-web/views"
+	"unicode/utf8"
+
+	"github.com/a-h/templ"
+)
+
+// metadataResults es cuántos juegos del catálogo se sugieren por búsqueda.
+const metadataResults = 8
+
+// coverExts son las extensiones de portada que se guardan en ImageDir.
+var coverExts = []string{".png", ".jpg", ".jpeg", ".webp", ".gif"}
+
+// MetadataSearchBox devuelve el buscador del catálogo para el formulario de
+// alta, o nada (204) si no hay catálogo configurado.
+func (h *Handler) MetadataSearchBox(w http.ResponseWriter, r *http.Request) {
+	if h.Metadata == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	templ.Handler(views.MetadataSearch()).ServeHTTP(w, r)
+}
+
+// SearchMetadata busca en el catálogo los juegos cuyo título contiene ?q=,
+// a partir de dos letras, para sugerirlos mientras se escribe.
+func (h *Handler) SearchMetadata(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	var games []metadata.Game
+	if utf8.RuneCountInString(query) >= 2 {
+		var err error
+		games, err = h.Metadata.Search(r.Context(), query, metadataResults)
+		if err != nil {
+			slog.WarnContext(r.Context(), "Error al buscar en el catálogo", "catalogo", h.Metadata.Name(), "q", query, "err", err)
+			http.Error(w, "metadata provider error", http.StatusBadGateway)
+			return
+		}
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, games)
+		return
+	}
+	views.MetadataResults(games).Render(r.Context(), w)
+}
+
+// MetadataGame vuelve a mostrar el formulario de alta completado con los
+// datos del juego del catálogo. Lo que ya se había cargado y el catálogo no
+// trae (estado, etiquetas) se conserva.
+func (h *Handler) MetadataGame(w http.ResponseWriter, r *http.Request) {
+	game, err := h.Metadata.Get(r.Context(), r.PathValue("id"))
+	if errors.Is(err, metadata.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		slog.WarnContext(r.Context(), "Error al consultar el catálogo", "catalogo", h.Metadata.Name(), "id_catalogo", r.PathValue("id"), "err", err)
+		http.Error(w, "metadata provider error", http.StatusBadGateway)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, game)
+		return
+	}
+
+	input, err := decodeInput(r, gameFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	form := views.EntityForm(prefillGame(input, game), nil, nil)
+	if isHTMX(r) {
+		form.Render(r.Context(), w)
+		return
+	}
+	views.Layout(form).Render(r.Context(), w)
+}
+
+// prefillGame completa el formulario con los datos del catálogo, recortados
+// a los largos de las columnas. Una fecha que no se entiende queda vacía.
+func prefillGame(input validation.GameInput, game metadata.Game) validation.GameInput {
+	input.Titulo = truncate(game.Title, validation.MaxTitulo)
+	input.Descripcion = truncate(game.Description, validation.MaxDescripcion)
+	input.Categoria = truncate(game.Category, validation.MaxCategoria)
+	input.Fecha, input.Precision = "", ""
+	if release, key := validation.ParseRelease(game.ReleaseDate, ""); key == "" {
+		input.Precision = release.Precision
+		if release.Precision != validation.PrecisionTBA {
+			input.Fecha = release.Date.Format(validation.DateLayout)
+		}
+	}
+	input.MetadataID = game.ID
+	return input
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max])
+}
+
+// saveCover descarga la portada del juego del catálogo en ImageDir y devuelve
+// la ruta que se guarda en la columna imagen. El nombre lleva un hash del id
+// del catálogo, así dos ids que dan el mismo slug no comparten archivo; si la
+// portada ya estaba (otro juego con el mismo id) se reutiliza sin pisarla.
+// file es el archivo escrito, vacío si no se escribió ninguno, para borrarlo
+// si el alta falla.
+func (h *Handler) saveCover(ctx context.Context, id string) (imagen, file string, err error) {
+	cover, err := h.Metadata.Cover(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+	ext := strings.ToLower(cover.Ext)
+	if !slices.Contains(coverExts, ext) {
+		return "", "", fmt.Errorf("extensión de portada no admitida %q", cover.Ext)
+	}
+
+	// "img/cover-<id>-<hash>.png" tiene que entrar en la columna imagen
+	sum := sha256.Sum256([]byte(id))
+	hash := hex.EncodeToString(sum[:4])
+	name := "cover-" + hash + ext
+	if slug := fileSlug(id, validation.MaxImagen-len("img/cover--")-len(hash)-len(ext)); slug != "" {
+		name = "cover-" + slug + "-" + hash + ext
+	}
+	file = filepath.Join(h.ImageDir, name)
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return "img/" + name, "", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+	_, err = f.Write(cover.Data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file)
+		return "", "", err
+	}
+	return "img/" + name, file, nil
+}
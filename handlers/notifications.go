@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// Notifications lista los avisos más recientes, del más nuevo al más viejo.
+func (h *Handler) Notifications(w http.ResponseWriter, r *http.Request) {
+	items, err := h.Games.ListNotifications(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar avisos", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, items)
+		return
+	}
+	templ.Handler(views.Layout(views.Notifications(items))).ServeHTTP(w, r)
+}
+
+// NotificationCount devuelve la cantidad de avisos sin leer; el encabezado la
+// pide con HTMX para mostrarla junto al enlace.
+func (h *Handler) NotificationCount(w http.ResponseWriter, r *http.Request) {
+	count, err := h.Games.CountUnreadNotifications(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al contar avisos", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, map[string]int64{"unread": count})
+		return
+	}
+	templ.Handler(views.NotificationCount(count)).ServeHTTP(w, r)
+}
+
+// MarkNotificationsRead marca todos los avisos como leídos.
+func (h *Handler) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.Games.MarkNotificationsRead(r.Context()); err != nil {
+		slog.ErrorContext(r.Context(), "Error al marcar avisos como leídos", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/notifications", http.StatusSeeOther)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/validation"
+	views "tp-web/views"
+)
+
+// playHistory junta el estado de juego y las sesiones de un juego para la
+// página de detalle.
+func (h *Handler) playHistory(ctx context.Context, id int32) (views.PlayHistory, error) {
+	var play views.PlayHistory
+	progress, err := h.Games.GetGameProgress(ctx, id)
+	switch {
+	case err == nil:
+		play.Progress = progress
+	case !errors.Is(err, sql.ErrNoRows):
+		return play, err
+	}
+
+	play.Sessions, err = h.Games.ListPlaySessions(ctx, id)
+	return play, err
+}
+
+// SetProgress cambia el estado de juego (backlog, jugando, terminado, ...).
+func (h *Handler) SetProgress(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, progressFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de estado de juego inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	completedOn, errs := validation.ValidateProgress(input)
+	if len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	progress, err := h.Service.SetProgress(r.Context(), id, input.Status, completedOn)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al cambiar el estado de juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Estado de juego cambiado", "id", id, "play_status", progress.PlayStatus)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, progress)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// LogSession registra una sesión de juego ya terminada.
+func (h *Handler) LogSession(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, sessionFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de sesión inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	start, end, errs := validation.ValidateSession(input, time.Local)
+	if len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	h.recordSession(w, r, datos.CreatePlaySessionParams{
+		GameID:    id,
+		StartedAt: start,
+		EndedAt:   sql.NullTime{Time: end, Valid: true},
+		Notes:     input.Notes,
+	})
+}
+
+// StartSession empieza una sesión de juego ahora; queda en curso hasta
+// StopSession.
+func (h *Handler) StartSession(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+	h.recordSession(w, r, datos.CreatePlaySessionParams{GameID: id, StartedAt: time.Now()})
+}
+
+func (h *Handler) recordSession(w http.ResponseWriter, r *http.Request, arg datos.CreatePlaySessionParams) {
+	session, err := h.Service.RecordSession(r.Context(), arg)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al registrar sesión de juego", "id", arg.GameID, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusCreated, session)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", arg.GameID), http.StatusSeeOther)
+}
+
+// StopSession termina la sesión en curso del juego.
+func (h *Handler) StopSession(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.Games.EndPlaySession(r.Context(), datos.EndPlaySessionParams{
+		GameID:  id,
+		EndedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al terminar sesión de juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, session)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// progressFromForm lee el estado de juego desde el formulario.
+func progressFromForm(r *http.Request) (validation.ProgressInput, error) {
+	return validation.ProgressInput{
+		Status:      r.FormValue("play_status"),
+		CompletedOn: r.FormValue("completed_on"),
+	}, nil
+}
+
+// sessionFromForm lee una sesión de juego desde el formulario.
+func sessionFromForm(r *http.Request) (validation.SessionInput, error) {
+	input := validation.SessionInput{
+		Start: r.FormValue("started_at"),
+		End:   r.FormValue("ended_at"),
+		Notes: r.FormValue("notes"),
+		TZ:    r.FormValue("tz"),
+	}
+	// El formulario admite coma decimal
+	if hours := strings.TrimSpace(r.FormValue("hours")); hours != "" {
+		var err error
+		if input.Hours, err = strconv.ParseFloat(strings.Replace(hours, ",", ".", 1), 64); err != nil {
+			return input, fmt.Errorf("horas inválidas: %w", err)
+		}
+	}
+	return input, nil
+}
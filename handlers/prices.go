@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	datos "tp-web/db/sqlc"
+	"tp-web/validation"
+	views "tp-web/views"
+)
+
+// priceHistory junta el precio objetivo (si hay) y los precios observados de
+// un juego para la página de detalle.
+func (h *Handler) priceHistory(ctx context.Context, id int32) (views.PriceHistory, error) {
+	var prices views.PriceHistory
+	watch, err := h.Games.GetPriceWatch(ctx, id)
+	switch {
+	case err == nil:
+		prices.Watch = &watch
+	case !errors.Is(err, sql.ErrNoRows):
+		return prices, err
+	}
+
+	prices.Observations, err = h.Games.ListPriceObservations(ctx, id)
+	return prices, err
+}
+
+// SetPriceWatch guarda el precio objetivo de un juego deseado; si ya tenía
+// uno, lo reemplaza.
+func (h *Handler) SetPriceWatch(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, priceFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de precio inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	game, err := h.Games.GetGame(r.Context(), id)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	cents, errs := validation.ValidatePrice(input)
+	if game.Estado != "deseado" {
+		errs["state"] = "validation.price_watch.not_wanted"
+	}
+	if len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	watch, err := h.Games.UpsertPriceWatch(r.Context(), datos.UpsertPriceWatchParams{
+		GameID:      id,
+		TargetCents: cents,
+		Currency:    input.Currency,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al guardar precio objetivo", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Precio objetivo guardado", "id", id, "target_cents", cents, "currency", input.Currency)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, watch)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// DeletePriceWatch quita el precio objetivo de un juego. Los precios ya
+// observados se conservan.
+func (h *Handler) DeletePriceWatch(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Games.DeletePriceWatch(r.Context(), id); err != nil {
+		slog.ErrorContext(r.Context(), "Error al quitar precio objetivo", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// RecordPrice registra a mano un precio observado del juego. Si cruza el
+// precio objetivo se genera el aviso, igual que con las fuentes automáticas.
+func (h *Handler) RecordPrice(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, priceFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de precio inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	cents, errs := validation.ValidatePrice(input)
+	if len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	observation, notified, err := h.Service.RecordPrice(r.Context(), datos.CreatePriceObservationParams{
+		GameID:     id,
+		PriceCents: cents,
+		Currency:   input.Currency,
+		Source:     "manual",
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al registrar precio", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusCreated, map[string]any{"observation": observation, "notified": notified})
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// priceFromForm lee un precio desde el formulario.
+func priceFromForm(r *http.Request) (validation.PriceInput, error) {
+	return validation.PriceInput{
+		Price:    r.FormValue("price"),
+		Currency: r.FormValue("currency"),
+	}, nil
+}
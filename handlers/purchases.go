@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/i18n"
+	"tp-web/validation"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// NewPurchase muestra el formulario para registrar una compra del juego, con
+// la fecha de hoy ya cargada.
+func (h *Handler) NewPurchase(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	game, err := h.Games.GetGame(r.Context(), id)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener juego", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	input := validation.PurchaseInput{Date: time.Now().Format(validation.DateLayout), Format: "physical"}
+	templ.Handler(views.Layout(views.PurchaseForm(game, input, nil))).ServeHTTP(w, r)
+}
+
+// CreatePurchase registra una compra del juego y lo pasa a "comprado".
+func (h *Handler) CreatePurchase(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, purchaseFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de compra inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	cents, date, errs := validation.ValidatePurchase(input)
+	if len(errs) > 0 {
+		if wantsJSON(r) {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": errs.Translate(i18n.LangFrom(r.Context()))})
+			return
+		}
+		game, err := h.Games.GetGame(r.Context(), id)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "Error al obtener juego", "id", id, "err", err)
+			writeDBError(w, r, err)
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		views.Layout(views.PurchaseForm(game, input, errs)).Render(r.Context(), w)
+		return
+	}
+
+	purchase, err := h.Service.RecordPurchase(r.Context(), datos.CreatePurchaseParams{
+		GameID:      id,
+		PriceCents:  cents,
+		Currency:    input.Currency,
+		Store:       input.Store,
+		PurchasedOn: date,
+		Format:      input.Format,
+		Notes:       input.Notes,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al registrar compra", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusCreated, purchase)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// purchaseFromForm lee la compra desde el formulario.
+func purchaseFromForm(r *http.Request) (validation.PurchaseInput, error) {
+	return validation.PurchaseInput{
+		Price:    r.FormValue("price"),
+		Currency: r.FormValue("currency"),
+		Store:    r.FormValue("store"),
+		Date:     r.FormValue("purchase_date"),
+		Format:   r.FormValue("format"),
+		Notes:    r.FormValue("notes"),
+	}, nil
+}
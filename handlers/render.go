@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"tp-web/db/dberrors"
+	datos "tp-web/db/sqlc"
+	"tp-web/i18n"
+	"tp-web/validation"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// decodeInput lee los datos que envía el cliente: de un cuerpo JSON si la
+// petición lo declara y, si no, del formulario con fromForm. En los dos casos
+// se devuelven con Trim aplicado.
+func decodeInput[T interface{ Trim() T }](r *http.Request, fromForm func(*http.Request) (T, error)) (T, error) {
+	var input T
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+			return input, err
+		}
+		return input.Trim(), nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return input, err
+	}
+	input, err := fromForm(r)
+	if err != nil {
+		return input, err
+	}
+	return input.Trim(), nil
+}
+
+// gameFromForm lee los datos del juego desde el formulario; en JSON es un
+// objeto con los mismos nombres de campo.
+func gameFromForm(r *http.Request) (validation.GameInput, error) {
+	return validation.GameInput{
+		Titulo:      r.FormValue("title"),
+		Descripcion: r.FormValue("description"),
+		Categoria:   r.FormValue("category"),
+		Plataforma:  r.FormValue("platform"),
+		Fecha:       r.FormValue("release_date"),
+		Precision:   r.FormValue("release_precision"),
+		Estado:      r.FormValue("state"),
+		Tags:        validation.SplitTags(r.FormValue("tags")),
+		MetadataID:  r.FormValue("metadata_id"),
+
+		ConfirmDuplicate: r.FormValue("confirm_duplicate") == "true",
+	}, nil
+}
+
+// bulkFromForm lee una acción masiva desde el formulario de la lista, con un
+// campo ids por cada juego marcado.
+func bulkFromForm(r *http.Request) (validation.BulkInput, error) {
+	var input validation.BulkInput
+	for _, s := range r.Form["ids"] {
+		id, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return input, fmt.Errorf("id inválida %q", s)
+		}
+		input.IDs = append(input.IDs, int32(id))
+	}
+	input.Action = r.FormValue("action")
+	input.Estado = r.FormValue("state")
+	input.Categoria = r.FormValue("category")
+	input.Tags = validation.SplitTags(r.FormValue("tags"))
+	return input, nil
+}
+
+// renderValidationErrors responde con los errores por campo: en JSON para la
+// API y, para el navegador, volviendo a mostrar el formulario con lo cargado.
+func renderValidationErrors(w http.ResponseWriter, r *http.Request, status int, input validation.GameInput, errs validation.Errors) {
+	if wantsJSON(r) {
+		writeJSON(w, status, map[string]any{"errors": errs.Translate(i18n.LangFrom(r.Context()))})
+		return
+	}
+	renderGameForm(w, r, status, views.EntityForm(input, errs, nil))
+}
+
+// renderFlashErrors responde a un formulario chico inválido (acción masiva,
+// precios): los errores por campo en JSON o, para el navegador, los mensajes
+// en #flash.
+func renderFlashErrors(w http.ResponseWriter, r *http.Request, errs validation.Errors) {
+	messages := errs.Translate(i18n.LangFrom(r.Context()))
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"errors": messages})
+		return
+	}
+
+	fields := slices.Sorted(maps.Keys(messages))
+	text := make([]string, len(fields))
+	for i, f := range fields {
+		text[i] = messages[f]
+	}
+	message := strings.Join(text, " ")
+
+	if isHTMX(r) {
+		w.Header().Set("HX-Retarget", "#flash")
+		w.Header().Set("HX-Reswap", "innerHTML")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		views.ErrorMessage(message).Render(r.Context(), w)
+		return
+	}
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	views.Layout(views.ErrorMessage(message)).Render(r.Context(), w)
+}
+
+// renderDuplicateWarning muestra los juegos con título parecido y deja que el
+// usuario confirme que se trata de un juego distinto.
+func renderDuplicateWarning(w http.ResponseWriter, r *http.Request, input validation.GameInput, similar []datos.ListSimilarGamesRow) {
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusConflict, map[string]any{
+			"error": map[string]string{
+				"code":    "possible_duplicate",
+				"message": i18n.T(r.Context(), "form.duplicate_api_detail"),
+			},
+			"similar": similar,
+		})
+		return
+	}
+	renderGameForm(w, r, http.StatusOK, views.EntityForm(input, nil, similar))
+}
+
+func renderGameForm(w http.ResponseWriter, r *http.Request, status int, form templ.Component) {
+	if isHTMX(r) {
+		// El formulario apunta a la lista; redirigimos el swap al propio formulario
+		w.Header().Set("HX-Retarget", "#gameFormSection")
+		w.Header().Set("HX-Reswap", "outerHTML")
+		w.WriteHeader(status)
+		form.Render(r.Context(), w)
+		return
+	}
+
+	w.WriteHeader(status)
+	views.Layout(form).Render(r.Context(), w)
+}
+
+// writeDBError traduce un error de la capa de datos a la respuesta HTTP que
+// corresponde: JSON para la API, un fragmento en #flash para HTMX o una página.
+func writeDBError(w http.ResponseWriter, r *http.Request, err error) {
+	dbErr := dberrors.Translate(err)
+	message := dbErr.Message(i18n.LangFrom(r.Context()))
+
+	if wantsJSON(r) {
+		writeJSON(w, dbErr.Status, map[string]any{"error": map[string]string{
+			"code":    dbErr.Code,
+			"field":   dbErr.Field,
+			"message": message,
+		}})
+		return
+	}
+
+	if isHTMX(r) {
+		w.Header().Set("HX-Retarget", "#flash")
+		w.Header().Set("HX-Reswap", "innerHTML")
+		w.WriteHeader(dbErr.Status)
+		views.ErrorMessage(message).Render(r.Context(), w)
+		return
+	}
+
+	w.WriteHeader(dbErr.Status)
+	views.Layout(views.ErrorMessage(message)).Render(r.Context(), w)
+}
+
+// isHTMX indica si la petición la hizo HTMX.
+func isHTMX(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
+
+// wantsJSON indica si el cliente pidió la respuesta en JSON.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Error al escribir respuesta JSON", "err", err)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	datos "tp-web/db/sqlc"
+	"tp-web/validation"
+)
+
+// SaveReview guarda el puntaje y la reseña de un autor; si ya había dejado
+// una para el juego, la reemplaza.
+func (h *Handler) SaveReview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, reviewFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de reseña inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	errs := validation.ValidateReview(input)
+	if len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	review, err := h.Games.UpsertReview(r.Context(), datos.UpsertReviewParams{
+		GameID:   id,
+		Reviewer: input.Reviewer,
+		Rating:   input.Rating,
+		Body:     input.Body,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al guardar reseña", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Reseña guardada", "id", id, "review", review.ID, "rating", input.Rating)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, review)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// DeleteReview borra una reseña del juego.
+func (h *Handler) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+	reviewID, err := pathInt32(r, "review")
+	if err != nil {
+		http.Error(w, "reseña inválida", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Games.DeleteReview(r.Context(), datos.DeleteReviewParams{ID: reviewID, GameID: id}); err != nil {
+		slog.ErrorContext(r.Context(), "Error al borrar reseña", "id", id, "review", reviewID, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// reviewFromForm lee una reseña desde el formulario.
+func reviewFromForm(r *http.Request) (validation.ReviewInput, error) {
+	// Un puntaje que no es número queda en cero, que ValidateReview rechaza
+	// como cualquier otro fuera de rango
+	rating, _ := strconv.ParseInt(strings.TrimSpace(r.FormValue("rating")), 10, 32)
+	return validation.ReviewInput{
+		Reviewer: r.FormValue("reviewer"),
+		Rating:   int32(rating),
+		Body:     r.FormValue("body"),
+	}, nil
+}
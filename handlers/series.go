@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	datos "tp-web/db/sqlc"
+	"tp-web/validation"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// gameSeries junta la serie del juego, las series para elegir y sus
+// ediciones. Un juego sin serie no es un error.
+func (h *Handler) gameSeries(ctx context.Context, id int32) (views.GameSeries, error) {
+	var series views.GameSeries
+	entry, err := h.Games.GetGameSeries(ctx, id)
+	switch {
+	case err == nil:
+		series.Entry = &entry
+	case !errors.Is(err, sql.ErrNoRows):
+		return series, err
+	}
+
+	if series.Series, err = h.Games.ListSeries(ctx); err != nil {
+		return series, err
+	}
+	series.Editions, err = h.Games.ListGameEditions(ctx, id)
+	return series, err
+}
+
+// ListSeries muestra las series con cuántas de sus entregas se tienen.
+func (h *Handler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	series, err := h.Games.ListSeries(r.Context())
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar las series", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, series)
+		return
+	}
+	templ.Handler(views.Layout(views.SeriesIndex(series))).ServeHTTP(w, r)
+}
+
+// CreateSeries crea una serie vacía y lleva a su página.
+func (h *Handler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	input, err := decodeInput(r, seriesFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de serie inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	if errs := validation.ValidateSeries(input); len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	series, err := h.Games.CreateSeries(r.Context(), input.Name)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al crear serie", "nombre", input.Name, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Serie creada", "serie", series.ID, "nombre", series.Name)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusCreated, series)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/series/%d", series.ID), http.StatusSeeOther)
+}
+
+// ShowSeries muestra las entregas de una serie por posición y cuántas se
+// tienen.
+func (h *Handler) ShowSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.Games.GetSeries(r.Context(), id)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al obtener serie", "serie", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	entries, err := h.Games.ListSeriesEntries(r.Context(), id)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al listar las entregas de la serie", "serie", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	page := views.SeriesPage{Series: series, Entries: entries}
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"series":  series,
+			"entries": entries,
+			"owned":   page.Owned(),
+			"total":   len(entries),
+		})
+		return
+	}
+	templ.Handler(views.Layout(views.SeriesDetail(page))).ServeHTTP(w, r)
+}
+
+// DeleteSeries borra una serie; sus juegos quedan sin serie.
+func (h *Handler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Games.DeleteSeries(r.Context(), id); err != nil {
+		slog.ErrorContext(r.Context(), "Error al borrar serie", "serie", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, "/series", http.StatusSeeOther)
+}
+
+// SetGameSeries agrega el juego a una serie en la posición indicada; si ya
+// pertenecía a otra, lo cambia.
+func (h *Handler) SetGameSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, seriesEntryFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de serie del juego inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	if errs := validation.ValidateSeriesEntry(input); len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	entry, err := h.Games.SetSeriesEntry(r.Context(), datos.SetSeriesEntryParams{
+		GameID:   id,
+		SeriesID: input.SeriesID,
+		Position: input.Position,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al cambiar la serie del juego", "id", id, "serie", input.SeriesID, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Serie del juego cambiada", "id", id, "serie", input.SeriesID, "posicion", input.Position)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, entry)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// DeleteGameSeries saca al juego de su serie.
+func (h *Handler) DeleteGameSeries(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Games.DeleteSeriesEntry(r.Context(), id); err != nil {
+		slog.ErrorContext(r.Context(), "Error al sacar el juego de su serie", "id", id, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// SaveEdition agrega una edición al juego o, si ya estaba, cambia si se
+// tiene.
+func (h *Handler) SaveEdition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	input, err := decodeInput(r, editionFromForm)
+	if err != nil {
+		slog.WarnContext(r.Context(), "Formulario de edición inválido", "err", err)
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	if errs := validation.ValidateEdition(input); len(errs) > 0 {
+		renderFlashErrors(w, r, errs)
+		return
+	}
+
+	edition, err := h.Games.UpsertGameEdition(r.Context(), datos.UpsertGameEditionParams{
+		GameID:  id,
+		Edition: input.Edition,
+		Owned:   input.Owned,
+	})
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al guardar edición", "id", id, "edicion", input.Edition, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+	slog.InfoContext(r.Context(), "Edición guardada", "id", id, "edicion", edition.Edition, "propia", edition.Owned)
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, edition)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// DeleteEdition quita una edición del juego.
+func (h *Handler) DeleteEdition(w http.ResponseWriter, r *http.Request) {
+	id, err := pathInt32(r, "id")
+	if err != nil {
+		http.Error(w, "id inválida", http.StatusBadRequest)
+		return
+	}
+
+	edition := r.PathValue("edition")
+	if err := h.Games.DeleteGameEdition(r.Context(), datos.DeleteGameEditionParams{GameID: id, Edition: edition}); err != nil {
+		slog.ErrorContext(r.Context(), "Error al borrar edición", "id", id, "edicion", edition, "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	http.Redirect(w, r, fmt.Sprintf("/games/%d", id), http.StatusSeeOther)
+}
+
+// seriesFromForm lee una serie desde el formulario.
+func seriesFromForm(r *http.Request) (validation.SeriesInput, error) {
+	return validation.SeriesInput{Name: r.FormValue("name")}, nil
+}
+
+// seriesEntryFromForm lee la serie de un juego desde el formulario.
+func seriesEntryFromForm(r *http.Request) (validation.SeriesEntryInput, error) {
+	// Un valor que no es número queda en cero, que ValidateSeriesEntry
+	// rechaza
+	seriesID, _ := strconv.ParseInt(strings.TrimSpace(r.FormValue("series_id")), 10, 32)
+	position, _ := strconv.ParseInt(strings.TrimSpace(r.FormValue("position")), 10, 32)
+	return validation.SeriesEntryInput{
+		SeriesID: int32(seriesID),
+		Position: int32(position),
+	}, nil
+}
+
+// editionFromForm lee una edición desde el formulario.
+func editionFromForm(r *http.Request) (validation.EditionInput, error) {
+	return validation.EditionInput{
+		Edition: r.FormValue("edition"),
+		Owned:   r.FormValue("owned") == "true",
+	}, nil
+}
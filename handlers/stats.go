@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+	"tp-web/i18n"
+	"tp-web/repository"
+	views "tp-web/views"
+
+	"github.com/a-h/templ"
+)
+
+// Stats muestra el resumen de la colección con sus gráficos, o lo devuelve
+// en JSON si se pide.
+func (h *Handler) Stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := libraryStats(r.Context(), h.Games)
+	if err != nil {
+		slog.ErrorContext(r.Context(), "Error al calcular las estadísticas", "err", err)
+		writeDBError(w, r, err)
+		return
+	}
+
+	if wantsJSON(r) {
+		writeJSON(w, http.StatusOK, stats)
+		return
+	}
+
+	templ.Handler(views.Layout(views.Stats(stats))).ServeHTTP(w, r)
+}
+
+// maxYearGap es el hueco más largo entre años con juegos que se completa con
+// cero. Uno más largo (una fecha cargada como 0001 o 9999, por ejemplo) queda
+// como salto entre barras, para no dibujar miles de años vacíos.
+const maxYearGap = 10
+
+// libraryStats junta los conteos de la colección y los pasa a barras con las
+// etiquetas traducidas, junto con lo gastado en compras. Los años y meses sin
+// juegos se completan con cero para que los gráficos en el tiempo no salteen
+// períodos; entre años, solo hasta maxYearGap.
+func libraryStats(ctx context.Context, games repository.GameRepository) (views.LibraryStats, error) {
+	var stats views.LibraryStats
+
+	byEstado, err := games.CountGamesByEstado(ctx)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range byEstado {
+		stats.Total += row.Total
+		stats.ByEstado = append(stats.ByEstado, views.Bar{Label: i18n.T(ctx, "state."+row.Estado), Value: row.Total})
+	}
+
+	byCategoria, err := games.CountGamesByCategoria(ctx)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range byCategoria {
+		stats.ByCategoria = append(stats.ByCategoria, views.Bar{Label: row.Categoria, Value: row.Total})
+	}
+
+	byYear, err := games.CountGamesByReleaseYear(ctx)
+	if err != nil {
+		return stats, err
+	}
+	for i, row := range byYear {
+		if i > 0 && row.Year-byYear[i-1].Year <= maxYearGap {
+			for year := byYear[i-1].Year + 1; year < row.Year; year++ {
+				stats.ByYear = append(stats.ByYear, views.Bar{Label: strconv.Itoa(int(year)), Value: 0})
+			}
+		}
+		stats.ByYear = append(stats.ByYear, views.Bar{Label: strconv.Itoa(int(row.Year)), Value: row.Total})
+	}
+
+	byMonth, err := games.CountGamesAddedByMonth(ctx)
+	if err != nil {
+		return stats, err
+	}
+	if len(byMonth) > 0 {
+		totals := map[string]int64{}
+		for _, row := range byMonth {
+			totals[row.Month] = row.Total
+		}
+		first, err1 := time.Parse("2006-01", byMonth[0].Month)
+		last, err2 := time.Parse("2006-01", byMonth[len(byMonth)-1].Month)
+		if err1 != nil || err2 != nil {
+			return stats, fmt.Errorf("mes inválido en las estadísticas: %q, %q", byMonth[0].Month, byMonth[len(byMonth)-1].Month)
+		}
+		for m := first; !m.After(last); m = m.AddDate(0, 1, 0) {
+			stats.ByMonth = append(stats.ByMonth, views.Bar{Label: monthLabel(ctx, m), Value: totals[m.Format("2006-01")]})
+		}
+	}
+
+	spendByMonth, err := games.SpendByMonth(ctx)
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range spendByMonth {
+		label := row.Month
+		if m, err := time.Parse("2006-01", row.Month); err == nil {
+			label = monthLabel(ctx, m)
+		}
+		stats.SpendByMonth = append(stats.SpendByMonth, views.Spend{Label: label, Currency: row.Currency, Cents: row.TotalCents})
+	}
+
+	spendByCategoria, err := games.SpendByCategoria(ctx)
+	if err != nil {
+		return stats, err
+	}
+	totals := map[string]int64{}
+	for _, row := range spendByCategoria {
+		stats.SpendByCategoria = append(stats.SpendByCategoria, views.Spend{Label: row.Categoria, Currency: row.Currency, Cents: row.TotalCents})
+		totals[row.Currency] += row.TotalCents
+	}
+	for _, currency := range slices.Sorted(maps.Keys(totals)) {
+		stats.SpendTotal = append(stats.SpendTotal, views.Spend{Currency: currency, Cents: totals[currency]})
+	}
+
+	return stats, nil
+}
+
+// monthLabel escribe el mes en el idioma del contexto, por ejemplo "marzo 2025".
+func monthLabel(ctx context.Context, m time.Time) string {
+	return fmt.Sprintf("%s %d", i18n.T(ctx, "month."+strconv.Itoa(int(m.Month()))), m.Year())
+}
@@ -0,0 +1,195 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Idiomas soportados por la interfaz
+const (
+	ES = "es"
+	EN = "en"
+)
+
+// Idioma por defecto cuando el navegador no pide ninguno soportado
+const Default = ES
+
+// Nombre de la cookie que guarda el idioma elegido por el usuario
+const CookieName = "lang"
+
+type ctxKey struct{}
+
+// Supported indica si lang es uno de los idiomas del catálogo.
+func Supported(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
+
+// WithLang devuelve un contexto que lleva el idioma de la petición.
+func WithLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, lang)
+}
+
+// LangFrom devuelve el idioma guardado en el contexto o el idioma por defecto.
+func LangFrom(ctx context.Context) string {
+	if lang, ok := ctx.Value(ctxKey{}).(string); ok {
+		return lang
+	}
+	return Default
+}
+
+// T traduce key al idioma del contexto. Si se pasan args, el mensaje se usa
+// como formato de fmt.Sprintf.
+func T(ctx context.Context, key string, args ...any) string {
+	return Translate(LangFrom(ctx), key, args...)
+}
+
+// Translate traduce key al idioma indicado. Si falta la traducción se usa la
+// del idioma por defecto y, si tampoco existe, la propia clave.
+func Translate(lang, key string, args ...any) string {
+	msg, ok := catalog[lang][key]
+	if !ok {
+		msg, ok = catalog[Default][key]
+	}
+	if !ok {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Negotiate elige el idioma de la petición: primero la cookie elegida por el
+// usuario y después el header Accept-Language.
+func Negotiate(r *http.Request) string {
+	if c, err := r.Cookie(CookieName); err == nil && Supported(c.Value) {
+		return c.Value
+	}
+	return fromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// fromAcceptLanguage devuelve el idioma soportado con mayor peso "q" en un
+// header como "en-US,en;q=0.9,es;q=0.8".
+func fromAcceptLanguage(header string) string {
+	type option struct {
+		lang string
+		q    float64
+	}
+	var options []option
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !Supported(lang) {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		options = append(options, option{lang, q})
+	}
+	if len(options) == 0 {
+		return Default
+	}
+	sort.SliceStable(options, func(i, j int) bool { return options[i].q > options[j].q })
+	return options[0].lang
+}
+
+// Middleware guarda en el contexto de cada petición el idioma negociado.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithLang(r.Context(), Negotiate(r))))
+	})
+}
+
+// FormatDate convierte una fecha "AAAA-MM-DD" al formato largo del idioma del
+// contexto. Si no se puede parsear se devuelve tal cual.
+func FormatDate(ctx context.Context, date string) string {
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	month := T(ctx, "month."+strconv.Itoa(int(d.Month())))
+	if LangFrom(ctx) == EN {
+		return fmt.Sprintf("%s %d, %d", month, d.Day(), d.Year())
+	}
+	return fmt.Sprintf("%d de %s de %d", d.Day(), month, d.Year())
+}
+
+// FormatRelease escribe una fecha de salida según su precisión: la fecha
+// completa, "marzo de 2026", "T3 2026", "2026" o "Por anunciar". date es el
+// último día del período, como se guarda en la columna fecha.
+func FormatRelease(ctx context.Context, date, precision string) string {
+	if precision == "tba" {
+		return T(ctx, "release.tba")
+	}
+	d, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	switch precision {
+	case "month":
+		month := T(ctx, "month."+strconv.Itoa(int(d.Month())))
+		if LangFrom(ctx) == EN {
+			return fmt.Sprintf("%s %d", month, d.Year())
+		}
+		return fmt.Sprintf("%s de %d", month, d.Year())
+	case "quarter":
+		return T(ctx, "release.quarter", (int(d.Month())+2)/3, d.Year())
+	case "year":
+		return strconv.Itoa(d.Year())
+	}
+	return FormatDate(ctx, date)
+}
+
+// FormatMoney escribe un importe en centavos con los separadores del idioma
+// del contexto: "1.234,50 USD" en español y "USD 1,234.50" en inglés.
+func FormatMoney(ctx context.Context, cents int64, currency string) string {
+	thousands, decimal := ".", ","
+	if LangFrom(ctx) == EN {
+		thousands, decimal = ",", "."
+	}
+
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	whole := strconv.FormatInt(cents/100, 10)
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	amount := fmt.Sprintf("%s%s%s%02d", sign, b.String(), decimal, cents%100)
+
+	if LangFrom(ctx) == EN {
+		return currency + " " + amount
+	}
+	return amount + " " + currency
+}
+
+// FormatDecimal escribe v con un decimal y el separador del idioma del
+// contexto: "8,5" en español y "8.5" en inglés.
+func FormatDecimal(ctx context.Context, v float64) string {
+	s := strconv.FormatFloat(v, 'f', 1, 64)
+	if LangFrom(ctx) != EN {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+	return s
+}
+
+// FormatHours escribe una duración en horas con un decimal: "12,5 h" en
+// español y "12.5 h" en inglés.
+func FormatHours(ctx context.Context, seconds int64) string {
+	return FormatDecimal(ctx, float64(seconds)/3600) + " h"
+}
@@ -0,0 +1,561 @@
+package i18n
+
+// catalog contiene los textos de la interfaz por idioma y clave.
+var catalog = map[string]map[string]string{
+	ES: {
+		"app.title":   "Biblioteca de Juegos",
+		"app.heading": "Lista de Juegos",
+		"lang.es":     "Español",
+		"lang.en":     "English",
+
+		"state.none":     "Sin estado",
+		"state.deseado":  "Deseado",
+		"state.comprado": "Comprado",
+
+		"field.title":                  "Título",
+		"field.description":            "Descripción",
+		"field.category":               "Categoría",
+		"field.platform":               "Plataforma",
+		"field.release_date":           "Fecha",
+		"field.state":                  "Estado",
+		"field.image":                  "Imagen",
+		"field.tags":                   "Etiquetas",
+		"field.tags_hint":              "Separadas por coma, por ejemplo: rpg, mundo abierto",
+		"field.release_precision":      "Precisión de la fecha",
+		"field.release_precision_hint": "Para un mes, trimestre o año elegí cualquier día del período; «Por anunciar» no necesita fecha.",
+		"precision.day":                "Día exacto",
+		"precision.month":              "Mes",
+		"precision.quarter":            "Trimestre",
+		"precision.year":               "Año",
+		"precision.tba":                "Por anunciar",
+		"release.quarter":              "T%d %d",
+		"release.tba":                  "Por anunciar",
+		"field.price":                  "Precio",
+		"field.currency":               "Moneda",
+		"field.store":                  "Tienda",
+		"field.purchase_date":          "Fecha de compra",
+		"field.format":                 "Formato",
+		"field.notes":                  "Notas",
+		"field.reviewer":               "Autor",
+		"field.rating":                 "Puntaje",
+		"field.series":                 "Serie",
+		"field.series_name":            "Nombre de la serie",
+		"field.position":               "Posición",
+		"field.edition":                "Edición",
+		"field.owned":                  "La tengo",
+		"field.review_body":            "Reseña (opcional)",
+
+		"format.physical": "Físico",
+		"format.digital":  "Digital",
+
+		"list.empty":          "No hay juegos registrados.",
+		"list.image_alt":      "Imagen de %s",
+		"list.delete":         "Eliminar Juego",
+		"list.delete_confirm": "¿Estás seguro de que deseas eliminar este juego?",
+		"list.sort":           "Ordenar por",
+		"list.sort.title":     "Título",
+		"list.sort.rating":    "Puntaje",
+		"list.sort.release":   "Fecha de salida",
+		"list.group.series":   "Agrupar por serie",
+
+		"bulk.select":               "Seleccionar %s",
+		"bulk.action":               "Acción",
+		"bulk.action.delete":        "Eliminar",
+		"bulk.action.set_state":     "Cambiar estado",
+		"bulk.action.set_category":  "Cambiar categoría",
+		"bulk.action.add_tags":      "Agregar etiquetas",
+		"bulk.apply":                "Aplicar a los seleccionados",
+		"bulk.confirm":              "¿Aplicar la acción a los juegos seleccionados?",
+		"bulk.summary.delete":       "Se eliminaron %d de %d juegos seleccionados.",
+		"bulk.summary.set_state":    "Se cambió el estado de %d de %d juegos seleccionados.",
+		"bulk.summary.set_category": "Se cambió la categoría de %d de %d juegos seleccionados.",
+		"bulk.summary.add_tags":     "Se agregaron etiquetas a %d de %d juegos seleccionados.",
+
+		"form.heading":              "Agregar Nuevo Juego",
+		"form.release_date":         "Fecha de salida",
+		"form.platform":             "Plataforma (opcional)",
+		"form.state_placeholder":    "-- Seleccioná un estado --",
+		"form.submit":               "Agregar Juego",
+		"metadata.search":           "Buscar en el catálogo para completar los datos",
+		"metadata.cover_note":       "Se va a descargar la portada del catálogo al guardar.",
+		"form.duplicate_question":   "¿Quisiste decir un juego existente?",
+		"form.duplicate_confirm":    "Es un juego distinto, guardarlo igual",
+		"form.duplicate_api_detail": "Hay juegos con un título parecido; reenviá con confirm_duplicate para guardarlo igual.",
+
+		"detail.back":           "Volver a la lista",
+		"detail.history":        "Historial de cambios",
+		"detail.no_revisions":   "Este juego no tiene revisiones registradas.",
+		"detail.revision":       "Revisión %d",
+		"detail.reverted_from":  "Revertido a la revisión %d",
+		"detail.revert":         "Revertir a esta revisión",
+		"detail.revert_confirm": "¿Revertir el juego a esta revisión?",
+		"detail.current":        "Revisión actual",
+		"detail.no_tags":        "Sin etiquetas",
+
+		"stats.link":                "Estadísticas",
+		"stats.heading":             "Estadísticas de la colección",
+		"stats.total":               "Juegos en total",
+		"stats.by_estado":           "Juegos por estado",
+		"stats.by_categoria":        "Juegos por categoría",
+		"stats.by_year":             "Juegos por año de salida",
+		"stats.by_month":            "Juegos agregados por mes",
+		"stats.spend":               "Gastos",
+		"stats.spend_total":         "Total gastado",
+		"stats.spend_by_month":      "Gastos por mes",
+		"stats.spend_by_categoria":  "Gastos por categoría",
+		"series.link":               "Series",
+		"series.heading":            "Series y franquicias",
+		"series.none":               "Todavía no hay series.",
+		"series.create":             "Crear serie",
+		"series.back":               "← Volver a las series",
+		"series.progress":           "%d de %d",
+		"series.no_entries":         "La serie todavía no tiene juegos.",
+		"series.owned":              "La tengo",
+		"series.delete":             "Eliminar serie",
+		"series.delete_confirm":     "¿Eliminar la serie? Sus juegos quedan sin serie.",
+		"series.game_heading":       "Serie",
+		"series.position":           "(entrega #%d)",
+		"series.no_series":          "No pertenece a ninguna serie.",
+		"series.save":               "Guardar serie",
+		"series.remove":             "Quitar de la serie",
+		"series.manage":             "Administrar series",
+		"series.ungrouped":          "Sin serie",
+		"edition.heading":           "Ediciones",
+		"edition.none":              "No se cargaron ediciones.",
+		"edition.save":              "Guardar edición",
+		"edition.delete":            "Quitar",
+		"edition.standard":          "Estándar",
+		"edition.deluxe":            "Deluxe",
+		"edition.goty":              "Juego del año (GOTY)",
+		"edition.ultimate":          "Ultimate",
+		"edition.collector":         "Coleccionista",
+		"calendar.link":             "Calendario",
+		"calendar.heading":          "Calendario de lanzamientos",
+		"calendar.prev":             "← Mes anterior",
+		"calendar.next":             "Mes siguiente →",
+		"calendar.upcoming":         "Deseados que salen en los próximos %d días",
+		"calendar.upcoming_none":    "Ningún juego deseado sale en esos días.",
+		"calendar.today":            "sale hoy",
+		"calendar.tomorrow":         "sale mañana",
+		"calendar.in_days":          "faltan %d días",
+		"calendar.subscribe":        "Suscribirse al calendario (.ics)",
+		"calendar.subscribe_wanted": "Solo los deseados (.ics)",
+		"calendar.feed_name":        "Lanzamientos",
+
+		"purchase.heading":      "Compras",
+		"purchase.none":         "No hay compras registradas.",
+		"purchase.add":          "Registrar otra compra",
+		"purchase.mark_bought":  "Marcar como comprado",
+		"purchase.form_heading": "Compra de %s",
+		"purchase.submit":       "Guardar compra",
+
+		"play.heading":            "Progreso",
+		"play.status":             "Estado de juego",
+		"play.status.backlog":     "Pendiente",
+		"play.status.playing":     "Jugando",
+		"play.status.finished":    "Terminado",
+		"play.status.abandoned":   "Abandonado",
+		"play.status.completed":   "Completado al 100%",
+		"play.completed_on":       "terminado el %s",
+		"play.completed_on_field": "Fecha de finalización",
+		"play.played":             "Tiempo jugado",
+		"play.save_status":        "Guardar estado",
+		"play.start":              "Empezar sesión",
+		"play.stop":               "Terminar sesión",
+		"play.no_sessions":        "No hay sesiones registradas.",
+		"play.started_at":         "Inicio",
+		"play.ended_at":           "Fin",
+		"play.duration":           "Duración",
+		"play.hours":              "Horas",
+		"play.in_session":         "En curso",
+		"play.log_session":        "Cargar una sesión",
+		"play.log_submit":         "Guardar sesión",
+		"play.now_heading":        "Jugando ahora",
+
+		"review.heading":        "Reseñas",
+		"review.none":           "Todavía no hay reseñas.",
+		"review.average":        "Puntaje promedio",
+		"review.score":          "%d/10",
+		"review.unrated":        "Sin puntaje",
+		"review.summary":        "%s / 10 (%d reseñas)",
+		"review.write":          "Escribir una reseña",
+		"review.markdown_hint":  "Admite Markdown: **negrita**, *cursiva*, listas y enlaces.",
+		"review.submit":         "Guardar reseña",
+		"review.delete":         "Borrar reseña",
+		"review.delete_confirm": "¿Borrar esta reseña?",
+
+		"price.heading":       "Seguimiento de precio",
+		"price.target":        "Precio objetivo",
+		"price.none":          "No hay precios registrados.",
+		"price.set_target":    "Guardar objetivo",
+		"price.remove_target": "Quitar objetivo",
+		"price.observed":      "Precio visto",
+		"price.record":        "Registrar precio",
+		"price.history":       "Evolución del precio (%s)",
+
+		"notifications.link":              "Avisos",
+		"notifications.heading":           "Avisos",
+		"notifications.none":              "No hay avisos.",
+		"notifications.new":               "Nuevo",
+		"notifications.mark_read":         "Marcar todos como leídos",
+		"notification.price_below_target": "%s bajó a %s, por debajo del precio objetivo.",
+
+		"validation.title.required":            "El título es obligatorio.",
+		"validation.title.too_long":            "El título no puede superar los 150 caracteres.",
+		"validation.description.required":      "La descripción es obligatoria.",
+		"validation.description.too_long":      "La descripción no puede superar los 255 caracteres.",
+		"validation.category.required":         "La categoría es obligatoria.",
+		"validation.category.too_long":         "La categoría no puede superar los 50 caracteres.",
+		"validation.platform.too_long":         "La plataforma no puede superar los 30 caracteres.",
+		"validation.image.too_long":            "El nombre de la imagen no puede superar los 50 caracteres; probá con un título más corto.",
+		"validation.state.invalid":             "Seleccioná un estado válido.",
+		"validation.tags.required":             "Escribí al menos una etiqueta.",
+		"validation.tags.too_many":             "No se pueden cargar más de 10 etiquetas.",
+		"validation.tags.too_long":             "Cada etiqueta puede tener hasta 30 caracteres.",
+		"validation.release_date.required":     "La fecha de salida es obligatoria.",
+		"validation.release_date.invalid":      "La fecha debe tener el formato AAAA-MM-DD, AAAA-MM, AAAA-QN, AAAA o TBA.",
+		"validation.release_precision.invalid": "La precisión de la fecha no es válida.",
+		"validation.bulk.ids.required":         "Seleccioná al menos un juego.",
+		"validation.bulk.ids.too_many":         "No se pueden modificar más de 500 juegos a la vez.",
+		"validation.bulk.action.invalid":       "Seleccioná una acción válida.",
+		"validation.bulk.state.comprado":       "Para pasar un juego a comprado, registrá la compra desde su página.",
+		"validation.price.required":            "El precio es obligatorio.",
+		"validation.price.invalid":             "El precio debe ser un número con hasta dos decimales, por ejemplo 59,99.",
+		"validation.currency.invalid":          "La moneda debe ser un código de tres letras, por ejemplo ARS o USD.",
+		"validation.store.required":            "La tienda es obligatoria.",
+		"validation.store.too_long":            "La tienda no puede superar los 50 caracteres.",
+		"validation.purchase_date.required":    "La fecha de compra es obligatoria.",
+		"validation.purchase_date.invalid":     "La fecha debe tener el formato AAAA-MM-DD.",
+		"validation.format.invalid":            "Seleccioná un formato válido.",
+		"validation.notes.too_long":            "Las notas no pueden superar los 255 caracteres.",
+		"validation.price_watch.not_wanted":    "Solo se puede seguir el precio de un juego deseado.",
+		"validation.play_status.invalid":       "Seleccioná un estado de juego válido.",
+		"validation.completed_on.invalid":      "La fecha debe tener el formato AAAA-MM-DD.",
+		"validation.completed_on.not_finished": "Solo un juego terminado tiene fecha de finalización.",
+		"validation.started_at.required":       "El inicio de la sesión es obligatorio.",
+		"validation.started_at.invalid":        "El inicio de la sesión no es una fecha y hora válida.",
+		"validation.ended_at.required":         "Indicá el fin de la sesión o cuántas horas duró.",
+		"validation.ended_at.invalid":          "El fin de la sesión no es una fecha y hora válida.",
+		"validation.ended_at.before_start":     "La sesión no puede terminar antes de empezar.",
+		"validation.ended_at.too_long":         "Una sesión no puede durar más de 24 horas.",
+		"validation.hours.invalid":             "Las horas deben ser un número mayor que cero.",
+		"validation.tz.invalid":                "No se reconoce la zona horaria del navegador.",
+		"validation.reviewer.required":         "El autor es obligatorio.",
+		"validation.reviewer.too_long":         "El autor no puede superar los 50 caracteres.",
+		"validation.rating.invalid":            "El puntaje debe ser un número del 1 al 10.",
+		"validation.series_name.required":      "El nombre de la serie es obligatorio.",
+		"validation.series_name.too_long":      "El nombre de la serie no puede superar los 100 caracteres.",
+		"validation.series_id.invalid":         "La serie no es válida.",
+		"validation.position.invalid":          "La posición debe ser un número mayor que 0.",
+		"validation.edition.invalid":           "La edición no es válida.",
+		"validation.body.too_long":             "La reseña no puede superar los 5000 caracteres.",
+
+		"db.not_found":                    "No se encontró el recurso pedido.",
+		"db.check_violation":              "Uno de los valores no es válido.",
+		"db.not_null_violation":           "Falta completar un campo obligatorio.",
+		"db.string_data_right_truncation": "Uno de los textos supera el largo máximo permitido.",
+		"db.unique_violation":             "Ya existe un registro con esos datos.",
+		"db.unique_violation.title":       "Ya existe un juego con ese título en esa plataforma.",
+		"db.foreign_key_violation":        "El registro está relacionado con otro que no existe o que todavía lo usa.",
+		"db.invalid_input":                "Uno de los valores tiene un formato inválido.",
+		"db.unexpected":                   "Error inesperado",
+
+		"month.1":   "enero",
+		"month.2":   "febrero",
+		"month.3":   "marzo",
+		"month.4":   "abril",
+		"month.5":   "mayo",
+		"month.6":   "junio",
+		"month.7":   "julio",
+		"month.8":   "agosto",
+		"month.9":   "septiembre",
+		"month.10":  "octubre",
+		"month.11":  "noviembre",
+		"month.12":  "diciembre",
+		"weekday.1": "lun",
+		"weekday.2": "mar",
+		"weekday.3": "mié",
+		"weekday.4": "jue",
+		"weekday.5": "vie",
+		"weekday.6": "sáb",
+		"weekday.7": "dom",
+	},
+	EN: {
+		"app.title":   "Game Library",
+		"app.heading": "Game List",
+		"lang.es":     "Español",
+		"lang.en":     "English",
+
+		"state.none":     "No status",
+		"state.deseado":  "Wishlist",
+		"state.comprado": "Bought",
+
+		"field.title":                  "Title",
+		"field.description":            "Description",
+		"field.category":               "Category",
+		"field.platform":               "Platform",
+		"field.release_date":           "Date",
+		"field.state":                  "Status",
+		"field.image":                  "Image",
+		"field.tags":                   "Tags",
+		"field.tags_hint":              "Comma separated, for example: rpg, open world",
+		"field.release_precision":      "Date precision",
+		"field.release_precision_hint": "For a month, quarter or year pick any day in it; TBA needs no date.",
+		"precision.day":                "Exact day",
+		"precision.month":              "Month",
+		"precision.quarter":            "Quarter",
+		"precision.year":               "Year",
+		"precision.tba":                "TBA",
+		"release.quarter":              "Q%d %d",
+		"release.tba":                  "TBA",
+		"field.price":                  "Price",
+		"field.currency":               "Currency",
+		"field.store":                  "Store",
+		"field.purchase_date":          "Purchase date",
+		"field.format":                 "Format",
+		"field.notes":                  "Notes",
+		"field.reviewer":               "Reviewer",
+		"field.rating":                 "Rating",
+		"field.series":                 "Series",
+		"field.series_name":            "Series name",
+		"field.position":               "Position",
+		"field.edition":                "Edition",
+		"field.owned":                  "I own it",
+		"field.review_body":            "Review (optional)",
+
+		"format.physical": "Physical",
+		"format.digital":  "Digital",
+
+		"list.empty":          "There are no games yet.",
+		"list.image_alt":      "%s cover",
+		"list.delete":         "Delete Game",
+		"list.delete_confirm": "Are you sure you want to delete this game?",
+		"list.sort":           "Sort by",
+		"list.sort.title":     "Title",
+		"list.sort.rating":    "Rating",
+		"list.sort.release":   "Release date",
+		"list.group.series":   "Group by series",
+
+		"bulk.select":               "Select %s",
+		"bulk.action":               "Action",
+		"bulk.action.delete":        "Delete",
+		"bulk.action.set_state":     "Change status",
+		"bulk.action.set_category":  "Change category",
+		"bulk.action.add_tags":      "Add tags",
+		"bulk.apply":                "Apply to selected",
+		"bulk.confirm":              "Apply the action to the selected games?",
+		"bulk.summary.delete":       "Deleted %d of %d selected games.",
+		"bulk.summary.set_state":    "Changed the status of %d of %d selected games.",
+		"bulk.summary.set_category": "Changed the category of %d of %d selected games.",
+		"bulk.summary.add_tags":     "Added tags to %d of %d selected games.",
+
+		"form.heading":              "Add New Game",
+		"form.release_date":         "Release date",
+		"form.platform":             "Platform (optional)",
+		"form.state_placeholder":    "-- Select a status --",
+		"form.submit":               "Add Game",
+		"metadata.search":           "Search the catalog to fill in the details",
+		"metadata.cover_note":       "The catalog cover will be downloaded when saving.",
+		"form.duplicate_question":   "Did you mean an existing game?",
+		"form.duplicate_confirm":    "It is a different game, save it anyway",
+		"form.duplicate_api_detail": "There are games with a similar title; resend with confirm_duplicate to save it anyway.",
+
+		"detail.back":           "Back to the list",
+		"detail.history":        "Change history",
+		"detail.no_revisions":   "This game has no recorded revisions.",
+		"detail.revision":       "Revision %d",
+		"detail.reverted_from":  "Reverted to revision %d",
+		"detail.revert":         "Revert to this revision",
+		"detail.revert_confirm": "Revert the game to this revision?",
+		"detail.current":        "Current revision",
+		"detail.no_tags":        "No tags",
+
+		"stats.link":                "Statistics",
+		"stats.heading":             "Collection statistics",
+		"stats.total":               "Total games",
+		"stats.by_estado":           "Games by status",
+		"stats.by_categoria":        "Games by category",
+		"stats.by_year":             "Games by release year",
+		"stats.by_month":            "Games added per month",
+		"stats.spend":               "Spending",
+		"stats.spend_total":         "Total spent",
+		"stats.spend_by_month":      "Spending per month",
+		"stats.spend_by_categoria":  "Spending per category",
+		"series.link":               "Series",
+		"series.heading":            "Series and franchises",
+		"series.none":               "There are no series yet.",
+		"series.create":             "Create series",
+		"series.back":               "← Back to series",
+		"series.progress":           "%d of %d",
+		"series.no_entries":         "This series has no games yet.",
+		"series.owned":              "Owned",
+		"series.delete":             "Delete series",
+		"series.delete_confirm":     "Delete this series? Its games will have no series.",
+		"series.game_heading":       "Series",
+		"series.position":           "(entry #%d)",
+		"series.no_series":          "Not part of any series.",
+		"series.save":               "Save series",
+		"series.remove":             "Remove from series",
+		"series.manage":             "Manage series",
+		"series.ungrouped":          "No series",
+		"edition.heading":           "Editions",
+		"edition.none":              "No editions added.",
+		"edition.save":              "Save edition",
+		"edition.delete":            "Remove",
+		"edition.standard":          "Standard",
+		"edition.deluxe":            "Deluxe",
+		"edition.goty":              "Game of the Year (GOTY)",
+		"edition.ultimate":          "Ultimate",
+		"edition.collector":         "Collector's",
+		"calendar.link":             "Calendar",
+		"calendar.heading":          "Release calendar",
+		"calendar.prev":             "← Previous month",
+		"calendar.next":             "Next month →",
+		"calendar.upcoming":         "Wishlist games releasing in the next %d days",
+		"calendar.upcoming_none":    "No wishlist games release in that period.",
+		"calendar.today":            "out today",
+		"calendar.tomorrow":         "out tomorrow",
+		"calendar.in_days":          "in %d days",
+		"calendar.subscribe":        "Subscribe to the calendar (.ics)",
+		"calendar.subscribe_wanted": "Wishlist only (.ics)",
+		"calendar.feed_name":        "Releases",
+
+		"purchase.heading":      "Purchases",
+		"purchase.none":         "No purchases recorded.",
+		"purchase.add":          "Record another purchase",
+		"purchase.mark_bought":  "Mark as bought",
+		"purchase.form_heading": "Purchase of %s",
+		"purchase.submit":       "Save purchase",
+
+		"play.heading":            "Play progress",
+		"play.status":             "Play status",
+		"play.status.backlog":     "Backlog",
+		"play.status.playing":     "Playing",
+		"play.status.finished":    "Finished",
+		"play.status.abandoned":   "Abandoned",
+		"play.status.completed":   "100% completed",
+		"play.completed_on":       "finished on %s",
+		"play.completed_on_field": "Completion date",
+		"play.played":             "Time played",
+		"play.save_status":        "Save status",
+		"play.start":              "Start session",
+		"play.stop":               "Stop session",
+		"play.no_sessions":        "No play sessions recorded.",
+		"play.started_at":         "Started",
+		"play.ended_at":           "Ended",
+		"play.duration":           "Duration",
+		"play.hours":              "Hours",
+		"play.in_session":         "In progress",
+		"play.log_session":        "Log a session",
+		"play.log_submit":         "Save session",
+		"play.now_heading":        "Currently playing",
+
+		"review.heading":        "Reviews",
+		"review.none":           "No reviews yet.",
+		"review.average":        "Average rating",
+		"review.score":          "%d/10",
+		"review.unrated":        "Not rated",
+		"review.summary":        "%s / 10 (%d reviews)",
+		"review.write":          "Write a review",
+		"review.markdown_hint":  "Markdown is supported: **bold**, *italic*, lists and links.",
+		"review.submit":         "Save review",
+		"review.delete":         "Delete review",
+		"review.delete_confirm": "Delete this review?",
+
+		"price.heading":       "Price watch",
+		"price.target":        "Target price",
+		"price.none":          "No prices recorded.",
+		"price.set_target":    "Save target",
+		"price.remove_target": "Remove target",
+		"price.observed":      "Observed price",
+		"price.record":        "Record price",
+		"price.history":       "Price history (%s)",
+
+		"notifications.link":              "Notifications",
+		"notifications.heading":           "Notifications",
+		"notifications.none":              "No notifications.",
+		"notifications.new":               "New",
+		"notifications.mark_read":         "Mark all as read",
+		"notification.price_below_target": "%s dropped to %s, below the target price.",
+
+		"validation.title.required":            "Title is required.",
+		"validation.title.too_long":            "Title cannot be longer than 150 characters.",
+		"validation.description.required":      "Description is required.",
+		"validation.description.too_long":      "Description cannot be longer than 255 characters.",
+		"validation.category.required":         "Category is required.",
+		"validation.category.too_long":         "Category cannot be longer than 50 characters.",
+		"validation.platform.too_long":         "Platform cannot be longer than 30 characters.",
+		"validation.image.too_long":            "The image name cannot be longer than 50 characters; try a shorter title.",
+		"validation.state.invalid":             "Select a valid status.",
+		"validation.tags.required":             "Enter at least one tag.",
+		"validation.tags.too_many":             "A game cannot have more than 10 tags.",
+		"validation.tags.too_long":             "Each tag can be up to 30 characters long.",
+		"validation.release_date.required":     "Release date is required.",
+		"validation.release_date.invalid":      "Date must use the YYYY-MM-DD, YYYY-MM, YYYY-QN, YYYY or TBA format.",
+		"validation.release_precision.invalid": "Date precision is not valid.",
+		"validation.bulk.ids.required":         "Select at least one game.",
+		"validation.bulk.ids.too_many":         "You cannot change more than 500 games at once.",
+		"validation.bulk.action.invalid":       "Select a valid action.",
+		"validation.bulk.state.comprado":       "To mark a game as bought, record the purchase from its page.",
+		"validation.price.required":            "Price is required.",
+		"validation.price.invalid":             "Price must be a number with up to two decimals, for example 59.99.",
+		"validation.currency.invalid":          "Currency must be a three-letter code, for example USD or EUR.",
+		"validation.store.required":            "Store is required.",
+		"validation.store.too_long":            "Store cannot be longer than 50 characters.",
+		"validation.purchase_date.required":    "Purchase date is required.",
+		"validation.purchase_date.invalid":     "Date must use the YYYY-MM-DD format.",
+		"validation.format.invalid":            "Select a valid format.",
+		"validation.notes.too_long":            "Notes cannot be longer than 255 characters.",
+		"validation.price_watch.not_wanted":    "Only wishlist games can have a target price.",
+		"validation.play_status.invalid":       "Select a valid play status.",
+		"validation.completed_on.invalid":      "Date must use the YYYY-MM-DD format.",
+		"validation.completed_on.not_finished": "Only a finished game has a completion date.",
+		"validation.started_at.required":       "The session start is required.",
+		"validation.started_at.invalid":        "The session start is not a valid date and time.",
+		"validation.ended_at.required":         "Enter when the session ended or how many hours it lasted.",
+		"validation.ended_at.invalid":          "The session end is not a valid date and time.",
+		"validation.ended_at.before_start":     "The session cannot end before it starts.",
+		"validation.ended_at.too_long":         "A session cannot last more than 24 hours.",
+		"validation.hours.invalid":             "Hours must be a number greater than zero.",
+		"validation.tz.invalid":                "The browser's time zone is not recognized.",
+		"validation.reviewer.required":         "Reviewer is required.",
+		"validation.reviewer.too_long":         "Reviewer cannot be longer than 50 characters.",
+		"validation.rating.invalid":            "Rating must be a number from 1 to 10.",
+		"validation.series_name.required":      "Series name is required.",
+		"validation.series_name.too_long":      "Series name cannot be longer than 100 characters.",
+		"validation.series_id.invalid":         "Series is not valid.",
+		"validation.position.invalid":          "Position must be a number greater than 0.",
+		"validation.edition.invalid":           "Edition is not valid.",
+		"validation.body.too_long":             "The review cannot be longer than 5000 characters.",
+
+		"db.not_found":                    "The requested resource was not found.",
+		"db.check_violation":              "One of the values is not valid.",
+		"db.not_null_violation":           "A required field is missing.",
+		"db.string_data_right_truncation": "One of the values is longer than allowed.",
+		"db.unique_violation":             "A record with the same data already exists.",
+		"db.unique_violation.title":       "A game with that title already exists on that platform.",
+		"db.foreign_key_violation":        "The record references a missing record or is still referenced by another one.",
+		"db.invalid_input":                "One of the values has an invalid format.",
+		"db.unexpected":                   "Unexpected error",
+
+		"month.1":   "January",
+		"month.2":   "February",
+		"month.3":   "March",
+		"month.4":   "April",
+		"month.5":   "May",
+		"month.6":   "June",
+		"month.7":   "July",
+		"month.8":   "August",
+		"month.9":   "September",
+		"month.10":  "October",
+		"month.11":  "November",
+		"month.12":  "December",
+		"weekday.1": "Mon",
+		"weekday.2": "Tue",
+		"weekday.3": "Wed",
+		"weekday.4": "Thu",
+		"weekday.5": "Fri",
+		"weekday.6": "Sat",
+		"weekday.7": "Sun",
+	},
+}
@@ -0,0 +1,104 @@
+// Package ical arma calendarios en formato iCalendar (RFC 5545) para
+// suscribirse desde las aplicaciones de calendario.
+//
+// Solo genera lo que necesitan los lanzamientos: eventos de día completo con
+// título, descripción y enlace. Las líneas terminan en CRLF, el texto se
+// escapa y las líneas largas se pliegan a 75 bytes sin cortar caracteres.
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Event es un evento de día completo.
+type Event struct {
+	// UID identifica el evento entre actualizaciones del calendario; tiene
+	// que ser único y estable.
+	UID string
+	// Date es el día del evento; la hora se ignora.
+	Date        time.Time
+	Summary     string
+	Description string
+	// URL es un enlace absoluto al detalle; vacío si no hay.
+	URL string
+	// Stamp es cuándo se creó la información del evento.
+	Stamp time.Time
+}
+
+// Calendar es un calendario con nombre y sus eventos.
+type Calendar struct {
+	// ProdID identifica a quien genera el calendario, por ejemplo
+	// "-//tp-web//Lanzamientos//ES".
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// maxLine es el largo máximo de una línea en bytes, sin el CRLF.
+const maxLine = 75
+
+// WriteTo escribe el calendario en w.
+func (c Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	line := func(name, value string) {
+		fold(&b, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", escape(c.ProdID))
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(e.UID))
+		line("DTSTAMP", e.Stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", e.Date.Format("20060102"))
+		// DTEND es exclusivo: el evento ocupa solo el día de Date
+		line("DTEND;VALUE=DATE", e.Date.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		if e.URL != "" {
+			line("URL", e.URL)
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// escape escapa un valor de tipo TEXT.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// fold escribe la línea en b plegándola cada 75 bytes: las continuaciones
+// empiezan con un espacio, que cuenta dentro del largo.
+func fold(b *strings.Builder, s string) {
+	limit := maxLine
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = maxLine - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
@@ -0,0 +1,65 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"tp-web/ical"
+)
+
+func TestWriteTo(t *testing.T) {
+	cal := ical.Calendar{
+		ProdID: "-//tp-web//Lanzamientos//ES",
+		Name:   "Lanzamientos",
+		Events: []ical.Event{{
+			UID:         "game-7@tp-web",
+			Date:        time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			Summary:     "Zelda; edición, especial",
+			Description: "línea 1\nlínea 2",
+			URL:         "http://localhost:8080/games/7",
+			Stamp:       time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}},
+	}
+	var b strings.Builder
+	if _, err := cal.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Lanzamientos\r\n",
+		"UID:game-7@tp-web\r\n",
+		"DTSTAMP:20250102T030405Z\r\n",
+		"DTSTART;VALUE=DATE:20250331\r\n",
+		"DTEND;VALUE=DATE:20250401\r\n",
+		`SUMMARY:Zelda\; edición\, especial` + "\r\n",
+		`DESCRIPTION:línea 1\nlínea 2` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("falta %q en:\n%s", want, out)
+		}
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Errorf("hay saltos de línea sin CR:\n%q", out)
+	}
+}
+
+func TestFolding(t *testing.T) {
+	cal := ical.Calendar{Events: []ical.Event{{UID: "x", Summary: strings.Repeat("ñ", 100)}}}
+	var b strings.Builder
+	if _, err := cal.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, line := range strings.Split(b.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("línea %d de %d bytes: %q", i, len(line), line)
+		}
+	}
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	if want := "SUMMARY:" + strings.Repeat("ñ", 100) + "\r\n"; !strings.Contains(unfolded, want) {
+		t.Errorf("al desplegar falta %q en:\n%q", want, unfolded)
+	}
+}
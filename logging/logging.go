@@ -0,0 +1,64 @@
+// Package logging configura el logger de log/slog de la aplicación: salida
+// JSON (o texto), nivel modificable en tiempo de ejecución y los ids de la
+// petición y de la traza agregados a cada registro que se haga con un contexto.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"tp-web/middleware"
+	"tp-web/tracing"
+)
+
+// Level es el nivel mínimo de los logs. Se puede cambiar en cualquier
+// momento, por ejemplo desde /admin/log-level.
+var Level = new(slog.LevelVar)
+
+// ParseLevel acepta debug, info, warn o error (sin importar mayúsculas).
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, fmt.Errorf("nivel de log inválido %q: debe ser debug, info, warn o error", s)
+	}
+	return level, nil
+}
+
+// New crea un logger que escribe en w con el formato indicado ("json" o
+// "text") y el nivel de Level.
+func New(w io.Writer, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: Level}
+	var h slog.Handler
+	if format == "text" {
+		h = slog.NewTextHandler(w, opts)
+	} else {
+		h = slog.NewJSONHandler(w, opts)
+	}
+	return slog.New(contextHandler{h})
+}
+
+// contextHandler agrega request_id y trace_id a los registros hechos con un
+// contexto que los tenga (slog.InfoContext, slog.ErrorContext, ...).
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := middleware.RequestIDFrom(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if span := tracing.SpanFromContext(ctx); span.IsRecording() {
+		r.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
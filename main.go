@@ -2,158 +2,167 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"errors"
-	"log"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
 	"net/http"
-	"strconv"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
+	"tp-web/config"
 	db_connect "tp-web/db"
-	datos "tp-web/db/sqlc"
-	views "tp-web/views"
-
-	"github.com/a-h/templ"
+	"tp-web/handlers"
+	"tp-web/i18n"
+	"tp-web/logging"
+	"tp-web/metadata"
+	"tp-web/metrics"
+	"tp-web/middleware"
+	"tp-web/prices"
+	"tp-web/repository"
+	"tp-web/tracing"
 )
 
-var queries *datos.Queries
-var ctx context.Context
-
 func main() {
-
-	// Conexión a la base de datos
-	db, err := db_connect.InitDb()
-	if err != nil {
-		log.Fatalf("failed to connect to DB: %v", err)
+	if err := run(); err != nil {
+		slog.Error("El servidor terminó con error", "err", err)
+		os.Exit(1)
 	}
-	log.Println("Server connected to database successfully.")
-	defer db.Close()
-
-	queries = datos.New(db)
-	ctx = context.Background()
+}
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+// run levanta el servidor y bloquea hasta que termina. Al recibir SIGINT o
+// SIGTERM deja de aceptar conexiones, espera a que terminen las peticiones en
+// curso y cierra el pool de la base de datos.
+func run() error {
 
-		games, err := queries.ListGames(ctx)
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("configuración inválida: %w", err)
+	}
+	level, _ := logging.ParseLevel(cfg.Log.Level) // ya validado por config.Load
+	logging.Level.Set(level)
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log.Format))
+	slog.Info("Configuración efectiva", "config", cfg)
+
+	// Trazas: sin colector configurado los spans no se registran
+	if cfg.Tracing.Endpoint != "" {
+		headers, err := tracing.ParseHeaders(cfg.Tracing.Headers)
 		if err != nil {
-			log.Printf("Error en la capa de datos al listar todos los juegos: %v", err)
-			http.Error(w, "Error inesperado", http.StatusInternalServerError)
-			return
+			return fmt.Errorf("tracing.headers: %w", err)
 		}
+		tracer := tracing.NewTracer(tracing.NewOTLPExporter(cfg.Tracing.Endpoint, cfg.Tracing.ServiceName, headers))
+		tracing.SetTracer(tracer)
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := tracer.Shutdown(ctx); err != nil {
+				slog.Error("Error al exportar las últimas trazas", "err", err)
+			}
+		}()
+		slog.Info("Trazas activadas", "endpoint", cfg.Tracing.Endpoint)
+	}
 
-		log.Printf("Juegos recuperados: %v", len(games))
-
-		templ.Handler(views.IndexPage("Lista de Juegos", games)).ServeHTTP(w, r)
-
-	})
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Handler POST para crear un nuevo juego
-	http.HandleFunc("/games", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-			return
+	// Conexión a la base de datos
+	db, err := db_connect.InitDb(ctx, cfg.DB)
+	if err != nil {
+		return err
+	}
+	slog.Info("Conectado a la base de datos")
+	defer func() {
+		if err := db.Close(); err != nil {
+			slog.Error("Error al cerrar la conexión a la base de datos", "err", err)
 		}
+		slog.Info("Conexión a la base de datos cerrada")
+	}()
 
-		if err := r.ParseForm(); err != nil {
-			log.Printf("parse form error: %v", err)
-			http.Error(w, "invalid form", http.StatusBadRequest)
-			return
-		}
+	if err := db_connect.Migrate(ctx, db); err != nil {
+		return fmt.Errorf("no se pudo migrar la base de datos: %w", err)
+	}
 
-		title := r.FormValue("title")
-		description := r.FormValue("description")
-		category := r.FormValue("category")
-		state := r.FormValue("state")
-		// usar imagen por defecto para todos los juegos (no viene del form)
-		image := "img/" + title + ".jpg"
-		releaseStr := r.FormValue("release_date")
-
-		var releaseDate time.Time
-		if releaseStr != "" {
-			d, err := time.Parse("2006-01-02", releaseStr)
-			if err != nil {
-				log.Printf("invalid date: %v", err)
-				http.Error(w, "invalid date", http.StatusBadRequest)
-				return
-			}
-			releaseDate = d
-		}
+	h := handlers.New(repository.NewPostgresDB(db, tracing.WrapDB))
+	h.ImageDir = cfg.Storage.ImageDir
+	h.UpcomingDays = cfg.Calendar.UpcomingDays
+	h.DB = db
+	h.HTTPMetrics = metrics.NewHTTP()
 
-		_, err := queries.CreateGame(r.Context(), datos.CreateGameParams{
-			Titulo:      title,
-			Descripcion: description,
-			Categoria:   category,
-			Fecha:       releaseDate,
-			Estado:      state,
-			Imagen:      image,
-		})
+	// Consulta periódica de precios de los juegos deseados
+	if cfg.Prices.Source != "" {
+		source, err := prices.NewSource(cfg.Prices.Source)
 		if err != nil {
-			log.Printf("create game error: %v", err)
-			http.Error(w, "internal server error", http.StatusInternalServerError)
-			return
-		}
-
-		if r.Header.Get("HX-Request") == "true" { // If it's an HTMX request, only render the table
-
-			games, err := queries.ListGames(ctx)
-			if err != nil {
-				log.Printf("Error en la capa de datos al listar todos los juegos: %v", err)
-				http.Error(w, "Error inesperado", http.StatusInternalServerError)
-				return
-			}
-
-			views.EntityList(games).Render(r.Context(), w)
-			return
+			return err
 		}
+		go prices.NewWatcher(source, h.Games, h.Service).Run(ctx, cfg.Prices.Interval)
+		slog.Info("Consulta de precios activada", "fuente", source.Name(), "intervalo", cfg.Prices.Interval)
+	}
 
-	})
-
-	// Handler para DELETE /games/{id}
-	http.HandleFunc("/games/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodDelete {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
-			return
-		}
-		// Obtener el id de la URL "/games/{id}"
-		idStr := strings.TrimPrefix(r.URL.Path, "/games/")
-		if idStr == "" {
-			http.Error(w, "id no encontrada", http.StatusBadRequest)
-			return
-		}
-		println(idStr)
-		id, err := strconv.ParseInt(idStr, 10, 64)
-		println(id)
+	// Catálogo para completar los datos de los juegos al darlos de alta
+	if cfg.Metadata.Provider != "" {
+		provider, err := metadata.NewProvider(cfg.Metadata.Provider)
 		if err != nil {
-			http.Error(w, "id inválida", http.StatusBadRequest)
-			return
-		}
-		// Ejecutar delete usando r.Context()
-		if _, err := queries.DeleteGame(r.Context(), int32(id)); err != nil {
-			log.Printf("Error al eliminar juego id=%v: %v", id, err)
-			if errors.Is(err, sql.ErrNoRows) {
-				http.Error(w, "Game Not Found", http.StatusNotFound)
-				return
-			}
-			http.Error(w, "Error inesperado", http.StatusInternalServerError)
-			return
-		}
-		// Para peticiones HTMX devolvemos 200 OK con cuerpo vacío (HTMX removerá el target)
-		if r.Header.Get("HX-Request") == "true" {
-			w.WriteHeader(http.StatusOK)
-			return
+			return err
 		}
+		h.Metadata = provider
+		slog.Info("Búsqueda en catálogo activada", "catalogo", provider.Name())
+	}
 
-	})
+	// Las rutas de administración solo existen con autenticación configurada
+	if cfg.Auth.User != "" {
+		h.AdminAuth = middleware.RequireBasicAuth(cfg.Auth.User, cfg.Auth.Password)
+	}
 
-	// Handler para servir archivos estáticos de la carpeta "img"
-	http.Handle("/img/",
-		http.StripPrefix("/img/",
-			http.FileServer(http.Dir("img")),
-		),
+	// El primer middleware es el más externo
+	handler := middleware.Chain(h.Routes(),
+		middleware.RequestID,
+		middleware.Logging,
+		middleware.Recovery,
+		middleware.BasicAuth(cfg.Auth.User, cfg.Auth.Password),
+		i18n.Middleware,
+		// Los dos últimos leen el patrón de ruta que asigna el mux
+		tracing.Middleware,
+		h.HTTPMetrics.Middleware,
 	)
 
-	// Iniciar servidor en el puerto 8080
-	log.Println("Presentación servida en http://localhost:8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	srv := &http.Server{
+		Addr:              net.JoinHostPort(cfg.HTTP.Host, cfg.HTTP.Port),
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+	}
+	shutdownTimeout := cfg.HTTP.ShutdownTimeout
+
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("Presentación servida", "addr", "http://"+srv.Addr)
+		serverErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	// Apagado ordenado: esperar las peticiones en curso hasta shutdownTimeout
+	slog.Info("Señal recibida, apagando el servidor", "espera_maxima", shutdownTimeout.String())
+	stop()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	slog.Info("Servidor apagado")
+	return nil
 }
@@ -0,0 +1,202 @@
+// Package markdown convierte el Markdown de las reseñas a HTML seguro para
+// insertar en las páginas.
+//
+// Admite un subconjunto chico: párrafos, títulos (que se muestran como h4 a
+// h6 para no competir con los de la página), listas, citas, bloques de
+// código, código en línea, negrita, cursiva y enlaces. Todo el texto se
+// escapa y solo se generan esas etiquetas, así que el HTML que venga en la
+// reseña se muestra como texto. Los enlaces solo pueden ir a http, https,
+// mailto o a una ruta del sitio; los demás se muestran como texto.
+package markdown
+
+import (
+	"html"
+	"strings"
+)
+
+// ToHTML convierte src a HTML sanitizado.
+func ToHTML(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			i++
+			var code []string
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // cierre del bloque
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case heading(trimmed) > 0:
+			level := heading(trimmed)
+			tag := "h" + string(rune('0'+min(level+3, 6)))
+			b.WriteString("<" + tag + ">" + inline(strings.TrimSpace(trimmed[level:])) + "</" + tag + ">\n")
+			i++
+
+		case listItem(trimmed) != "":
+			tag := listItem(trimmed)
+			b.WriteString("<" + tag + ">\n")
+			for i < len(lines) && listItem(strings.TrimSpace(lines[i])) == tag {
+				b.WriteString("<li>" + inline(itemText(strings.TrimSpace(lines[i]))) + "</li>\n")
+				i++
+			}
+			b.WriteString("</" + tag + ">\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quote []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+				i++
+			}
+			b.WriteString("<blockquote><p>" + inline(strings.Join(quote, "\n")) + "</p></blockquote>\n")
+
+		default:
+			var para []string
+			for i < len(lines) && startsParagraph(lines[i]) {
+				para = append(para, strings.TrimSpace(lines[i]))
+				i++
+			}
+			b.WriteString("<p>" + inline(strings.Join(para, "\n")) + "</p>\n")
+		}
+	}
+	return b.String()
+}
+
+// heading devuelve el nivel de un título ("## Texto" es 2), o 0 si la línea
+// no es un título.
+func heading(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level == len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// listItem devuelve "ul" o "ol" si la línea es un ítem de lista.
+func listItem(line string) string {
+	if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ") || strings.HasPrefix(line, "+ ") {
+		return "ul"
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && strings.HasPrefix(line[digits:], ". ") {
+		return "ol"
+	}
+	return ""
+}
+
+func itemText(line string) string {
+	if listItem(line) == "ul" {
+		return line[2:]
+	}
+	_, text, _ := strings.Cut(line, ". ")
+	return text
+}
+
+// startsParagraph indica si la línea sigue el párrafo actual, es decir, que
+// no está vacía ni empieza otro tipo de bloque.
+func startsParagraph(line string) bool {
+	t := strings.TrimSpace(line)
+	return t != "" && heading(t) == 0 && listItem(t) == "" && !strings.HasPrefix(t, ">") && !strings.HasPrefix(t, "```")
+}
+
+// inline convierte el formato dentro de un bloque y escapa todo lo demás.
+func inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_[]()#+-.!>", rune(rest[1])):
+			b.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				b.WriteString("<strong>" + inline(rest[2:2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			// Un _ dentro de una palabra (snake_case) no es cursiva
+			inWord := rest[0] == '_' && i > 0 && isWordByte(s[i-1])
+			if end := strings.IndexByte(rest[1:], rest[0]); end > 0 && rest[1] != ' ' && !inWord {
+				b.WriteString("<em>" + inline(rest[1:1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case rest[0] == '[':
+			if text, url, n, ok := link(rest); ok {
+				if safeURL(url) {
+					b.WriteString(`<a href="` + html.EscapeString(url) + `" rel="nofollow noopener noreferrer">` + inline(text) + "</a>")
+				} else {
+					b.WriteString(inline(text))
+				}
+				i += n
+				continue
+			}
+
+		case rest[0] == '\n':
+			b.WriteString("<br>\n")
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+	return b.String()
+}
+
+// link reconoce "[texto](url)" al principio de s y devuelve cuántos bytes
+// ocupa.
+func link(s string) (text, url string, n int, ok bool) {
+	closeText := strings.Index(s, "](")
+	if closeText < 0 {
+		return "", "", 0, false
+	}
+	closeURL := strings.IndexByte(s[closeText+2:], ')')
+	if closeURL < 0 {
+		return "", "", 0, false
+	}
+	text = s[1:closeText]
+	url = strings.TrimSpace(s[closeText+2 : closeText+2+closeURL])
+	return text, url, closeText + 3 + closeURL, text != "" && url != ""
+}
+
+// safeURL acepta solo los esquemas que no ejecutan nada al seguir el
+// enlace, y las rutas del propio sitio.
+func safeURL(url string) bool {
+	lower := strings.ToLower(url)
+	for _, prefix := range []string{"http://", "https://", "mailto:"} {
+		if strings.HasPrefix(lower, prefix) {
+			return true
+		}
+	}
+	return strings.HasPrefix(url, "/") && !strings.HasPrefix(url, "//")
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
@@ -0,0 +1,52 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+	"tp-web/markdown"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"paragraphs", "Muy bueno.\n\nLo recomiendo.", "<p>Muy bueno.</p>\n<p>Lo recomiendo.</p>\n"},
+		{"line break", "uno\ndos", "<p>uno<br>\ndos</p>\n"},
+		{"emphasis", "**fuerte** y *suave* y _también_", "<p><strong>fuerte</strong> y <em>suave</em> y <em>también</em></p>\n"},
+		{"snake case", "mod_de_juego", "<p>mod_de_juego</p>\n"},
+		{"code", "usá `<b>` acá", "<p>usá <code>&lt;b&gt;</code> acá</p>\n"},
+		{"heading", "# Veredicto", "<h4>Veredicto</h4>\n"},
+		{"list", "- uno\n- dos\n\n1. primero", "<ul>\n<li>uno</li>\n<li>dos</li>\n</ul>\n<ol>\n<li>primero</li>\n</ol>\n"},
+		{"quote", "> cita", "<blockquote><p>cita</p></blockquote>\n"},
+		{"fence", "```\n<script>\n```", "<pre><code>&lt;script&gt;</code></pre>\n"},
+		{"link", "[web](https://example.com/?a=1&b=2)", `<p><a href="https://example.com/?a=1&amp;b=2" rel="nofollow noopener noreferrer">web</a></p>` + "\n"},
+		{"escaped", `\*no\*`, "<p>*no*</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := markdown.ToHTML(tt.src); got != tt.want {
+				t.Errorf("ToHTML(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToHTMLSanitizes(t *testing.T) {
+	for _, src := range []string{
+		`<script>alert(1)</script>`,
+		`<img src=x onerror=alert(1)>`,
+		`[clic](javascript:alert(1))`,
+		`[clic](JavaScript:alert(1))`,
+		`[clic](data:text/html;base64,PHNjcmlwdD4=)`,
+		`[clic](//evil.example)`,
+		`[x](https://a.example/" onmouseover="alert(1))`,
+		"**<iframe src=x>**",
+	} {
+		got := markdown.ToHTML(src)
+		for _, bad := range []string{"<script", "<img", "<iframe", `href="javascript`, `href="JavaScript`, `href="data`, `href="//`, `" onmouseover`} {
+			if strings.Contains(got, bad) {
+				t.Errorf("ToHTML(%q) = %q, contains %q", src, got, bad)
+			}
+		}
+	}
+}
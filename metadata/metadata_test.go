@@ -0,0 +1,56 @@
+package metadata_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/png"
+	"testing"
+	"tp-web/metadata"
+)
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	var p metadata.Provider = metadata.Fake{}
+
+	games, err := p.Search(ctx, "POKEMON", 5)
+	if err != nil || len(games) != 1 || games[0].ID != "pokemon-pokopia" {
+		t.Errorf("Search(POKEMON) = %+v, %v", games, err)
+	}
+	if games, _ := p.Search(ctx, "a", 2); len(games) != 2 {
+		t.Errorf("Search with limit 2 = %d games", len(games))
+	}
+	if games, _ := p.Search(ctx, "", 5); len(games) != 0 {
+		t.Errorf("empty search = %+v, want none", games)
+	}
+
+	game, err := p.Get(ctx, "hades-ii")
+	if err != nil || game.Title != "Hades II" {
+		t.Errorf("Get = %+v, %v", game, err)
+	}
+	if _, err := p.Get(ctx, "nope"); !errors.Is(err, metadata.ErrNotFound) {
+		t.Errorf("Get(nope) err = %v", err)
+	}
+
+	cover, err := p.Cover(ctx, "hades-ii")
+	if err != nil || cover.Ext != ".png" {
+		t.Fatalf("Cover = %v, %v", cover.Ext, err)
+	}
+	img, err := png.Decode(bytes.NewReader(cover.Data))
+	if err != nil || img.Bounds().Dx() != 120 {
+		t.Errorf("cover is not a 120px PNG: %v", err)
+	}
+	again, _ := p.Cover(ctx, "hades-ii")
+	if !bytes.Equal(cover.Data, again.Data) {
+		t.Errorf("the same game got a different cover")
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	if p, err := metadata.NewProvider("fake"); err != nil || p.Name() != "fake" {
+		t.Errorf("NewProvider(fake) = %v, %v", p, err)
+	}
+	if _, err := metadata.NewProvider("igdb"); err == nil {
+		t.Errorf("NewProvider(igdb) should fail")
+	}
+}
@@ -0,0 +1,198 @@
+// Package metrics expone métricas en el formato de texto de Prometheus sin
+// depender de la librería cliente.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"tp-web/middleware"
+)
+
+// Buckets son los límites (en segundos) del histograma de latencia.
+var Buckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+type routeStats struct {
+	statuses map[int]uint64
+	buckets  []uint64
+	sum      float64
+	count    uint64
+}
+
+// HTTP cuenta las peticiones y su latencia por ruta.
+type HTTP struct {
+	mu     sync.Mutex
+	routes map[routeKey]*routeStats
+}
+
+func NewHTTP() *HTTP {
+	return &HTTP{routes: map[routeKey]*routeStats{}}
+}
+
+// Observe registra una petición terminada.
+func (m *HTTP) Observe(method, route string, status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := routeKey{method, route}
+	stats, ok := m.routes[key]
+	if !ok {
+		stats = &routeStats{statuses: map[int]uint64{}, buckets: make([]uint64, len(Buckets))}
+		m.routes[key] = stats
+	}
+	stats.statuses[status]++
+	seconds := d.Seconds()
+	for i, le := range Buckets {
+		if seconds <= le {
+			stats.buckets[i]++
+		}
+	}
+	stats.sum += seconds
+	stats.count++
+}
+
+// Middleware mide cada petición usando como ruta el patrón del ServeMux
+// (por ejemplo "/games/{id}") para no generar una serie por cada id. Tiene
+// que ir justo antes del mux: el patrón se lee del *http.Request que recibe
+// el mux, y los middlewares que usan r.WithContext crean uno nuevo.
+func (m *HTTP) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := middleware.NewStatusRecorder(w)
+		defer func() {
+			m.Observe(method(r.Method), route(r.Pattern), rec.Status, time.Since(start))
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
+
+// methods son los métodos HTTP estándar; cualquier otro se cuenta como
+// "OTHER" para que un cliente no pueda crear series nuevas a voluntad.
+var methods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+func method(m string) string {
+	if slices.Contains(methods, m) {
+		return m
+	}
+	return "OTHER"
+}
+
+// route quita el método del patrón ("GET /games/{id}" → "/games/{id}").
+func route(pattern string) string {
+	if pattern == "" {
+		return "unmatched"
+	}
+	if _, path, ok := strings.Cut(pattern, " "); ok {
+		return path
+	}
+	return pattern
+}
+
+// Write escribe http_requests_total y http_request_duration_seconds.
+func (m *HTTP) Write(w *Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]routeKey, 0, len(m.routes))
+	for k := range m.routes {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	w.Header("http_requests_total", "counter", "Peticiones HTTP atendidas por ruta y código de estado.")
+	for _, k := range keys {
+		stats := m.routes[k]
+		statuses := make([]int, 0, len(stats.statuses))
+		for status := range stats.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			w.Sample("http_requests_total", float64(stats.statuses[status]), "method", k.method, "route", k.route, "status", strconv.Itoa(status))
+		}
+	}
+
+	w.Header("http_request_duration_seconds", "histogram", "Duración de las peticiones HTTP por ruta.")
+	for _, k := range keys {
+		stats := m.routes[k]
+		for i, le := range Buckets {
+			w.Sample("http_request_duration_seconds_bucket", float64(stats.buckets[i]), "method", k.method, "route", k.route, "le", formatFloat(le))
+		}
+		w.Sample("http_request_duration_seconds_bucket", float64(stats.count), "method", k.method, "route", k.route, "le", "+Inf")
+		w.Sample("http_request_duration_seconds_sum", stats.sum, "method", k.method, "route", k.route)
+		w.Sample("http_request_duration_seconds_count", float64(stats.count), "method", k.method, "route", k.route)
+	}
+}
+
+// ContentType es el tipo de la respuesta de /metrics.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Writer escribe métricas en el formato de texto de Prometheus. Guarda el
+// primer error de escritura y descarta lo que sigue.
+type Writer struct {
+	w   io.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Header escribe las líneas # HELP y # TYPE de una métrica.
+func (w *Writer) Header(name, typ, help string) {
+	w.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// Sample escribe un valor; labels son pares nombre, valor.
+func (w *Writer) Sample(name string, value float64, labels ...string) {
+	var b strings.Builder
+	b.WriteString(name)
+	if len(labels) > 0 {
+		b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(&b, "%s=\"%s\"", labels[i], labelEscaper.Replace(labels[i+1]))
+		}
+		b.WriteByte('}')
+	}
+	w.printf("%s %s\n", b.String(), formatFloat(value))
+}
+
+// Err devuelve el primer error de escritura.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+func (w *Writer) printf(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.w, format, args...)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,183 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// Middleware envuelve un http.Handler agregándole comportamiento.
+type Middleware func(http.Handler) http.Handler
+
+// Chain aplica los middlewares sobre h. El primero de la lista es el más
+// externo, es decir, el primero en recibir la petición.
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+	return h
+}
+
+// Header con el que se recibe y se devuelve el id de la petición
+const RequestIDHeader = "X-Request-ID"
+
+// Largo máximo del X-Request-ID que se acepta del cliente
+const maxRequestIDLen = 64
+
+// RequestID asigna un id a cada petición (o reutiliza el que llega en
+// X-Request-ID, si es válido), lo devuelve en la respuesta y lo guarda en el
+// contexto para que aparezca en los logs.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+type requestIDKey struct{}
+
+// RequestIDFrom devuelve el id de la petición guardado en el contexto. El
+// logger de logging lo agrega a cada registro.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// validRequestID acepta ids de hasta maxRequestIDLen caracteres de
+// [A-Za-z0-9._-], para no copiar cualquier cosa del cliente a la respuesta
+// y a los logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// StatusRecorder guarda el código de estado que escribió el handler. Lo usan
+// también las métricas y las trazas.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+// NewStatusRecorder envuelve w; si el handler no llama a WriteHeader el
+// estado queda en 200.
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+func (rec *StatusRecorder) WriteHeader(status int) {
+	rec.Status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *StatusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+// Logging registra un log de acceso por petición: método, ruta, estado,
+// duración y si la hizo HTMX. Las respuestas 5xx se registran como error.
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		level := slog.LevelInfo
+		if rec.Status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.LogAttrs(r.Context(), level, "petición",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.Status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Bool("htmx", r.Header.Get("HX-Request") == "true"),
+		)
+	})
+}
+
+// Recovery evita que un panic en un handler tire abajo el servidor y
+// responde 500 en su lugar.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+				slog.ErrorContext(r.Context(), "panic", "err", err, "stack", string(debug.Stack()))
+				http.Error(w, "Error inesperado", http.StatusInternalServerError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// BasicAuth pide usuario y contraseña para las peticiones que modifican datos
+// (todo lo que no sea GET o HEAD). Si user está vacío la autenticación queda
+// desactivada.
+func BasicAuth(user, password string) Middleware {
+	return func(next http.Handler) http.Handler {
+		if user == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+			requireAuth(next, user, password).ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireBasicAuth pide usuario y contraseña para todas las peticiones,
+// también las de lectura. Es para las rutas de administración, que no deben
+// quedar abiertas: user no puede estar vacío.
+func RequireBasicAuth(user, password string) Middleware {
+	if user == "" {
+		panic("middleware: RequireBasicAuth sin usuario")
+	}
+	return func(next http.Handler) http.Handler {
+		return requireAuth(next, user, password)
+	}
+}
+
+func requireAuth(next http.Handler, user, password string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, p, ok := r.BasicAuth()
+		if !ok ||
+			subtle.ConstantTimeCompare([]byte(u), []byte(user)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(p), []byte(password)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="tp-web"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,135 @@
+// Package prices consulta los precios de los juegos deseados en una fuente
+// de precios y los registra periódicamente para avisar cuando llegan al
+// precio objetivo.
+package prices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/repository"
+	"tp-web/service"
+)
+
+// Quote es un precio observado en una fuente.
+type Quote struct {
+	Cents    int32
+	Currency string
+}
+
+// ErrNotFound indica que la fuente no tiene precio para el juego.
+var ErrNotFound = errors.New("la fuente no tiene precio para el juego")
+
+// Source es una fuente de precios (una tienda, un comparador, ...).
+type Source interface {
+	// Name identifica la fuente en las observaciones guardadas.
+	Name() string
+	// Quote devuelve el precio actual del juego en la moneda pedida, o
+	// ErrNotFound si no lo tiene.
+	Quote(ctx context.Context, titulo, currency string) (Quote, error)
+}
+
+// NewSource devuelve la fuente configurada por nombre. Por ahora solo existe
+// "fake".
+func NewSource(name string) (Source, error) {
+	switch name {
+	case "fake":
+		return Fake{}, nil
+	default:
+		return nil, fmt.Errorf("fuente de precios desconocida %q", name)
+	}
+}
+
+// Fake es una fuente local para desarrollo: inventa un precio base a partir
+// del título y lo hace variar de un día a otro, siempre igual para el mismo
+// título y día.
+type Fake struct {
+	// Now devuelve la hora actual; nil usa time.Now.
+	Now func() time.Time
+}
+
+func (Fake) Name() string { return "fake" }
+
+func (f Fake) Quote(ctx context.Context, titulo, currency string) (Quote, error) {
+	now := time.Now
+	if f.Now != nil {
+		now = f.Now
+	}
+	h := fnv.New32a()
+	h.Write([]byte(titulo))
+	seed := h.Sum32()
+
+	// Entre 10 y 70 unidades, con una oscilación de ±30% en ciclos de unas semanas
+	base := 1000 + float64(seed%6000)
+	day := float64(now().Unix() / 86400)
+	swing := 0.3 * math.Sin(day/float64(5+seed%10)+float64(seed%7))
+	cents := int32(math.Round(base*(1+swing)/100)) * 100
+	return Quote{Cents: cents - 1, Currency: currency}, nil
+}
+
+// Watcher consulta periódicamente el precio de los juegos con precio
+// objetivo y lo guarda con service.Games.RecordPrice.
+type Watcher struct {
+	source Source
+	games  repository.GameRepository
+	svc    *service.Games
+}
+
+func NewWatcher(source Source, games repository.GameRepository, svc *service.Games) *Watcher {
+	return &Watcher{source: source, games: games, svc: svc}
+}
+
+// Run consulta los precios al arrancar y después cada interval, hasta que
+// se cancele ctx.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := w.CheckAll(ctx); err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "Error al consultar precios", "fuente", w.source.Name(), "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckAll consulta y registra el precio de cada juego deseado con precio
+// objetivo. Un juego que falla no impide consultar los demás; devuelve el
+// primer error.
+func (w *Watcher) CheckAll(ctx context.Context) error {
+	watches, err := w.games.ListPriceWatches(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, watch := range watches {
+		quote, err := w.source.Quote(ctx, watch.Titulo, watch.Currency)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err == nil {
+			_, _, err = w.svc.RecordPrice(ctx, datos.CreatePriceObservationParams{
+				GameID:     watch.GameID,
+				PriceCents: quote.Cents,
+				Currency:   quote.Currency,
+				Source:     w.source.Name(),
+			})
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Error al registrar precio", "id", watch.GameID, "fuente", w.source.Name(), "err", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
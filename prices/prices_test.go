@@ -0,0 +1,69 @@
+package prices_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/prices"
+	"tp-web/repository"
+	"tp-web/service"
+)
+
+// fixed devuelve siempre el mismo precio y no conoce los títulos de missing.
+type fixed struct {
+	cents   int32
+	missing string
+}
+
+func (fixed) Name() string { return "fixed" }
+
+func (f fixed) Quote(ctx context.Context, titulo, currency string) (prices.Quote, error) {
+	if titulo == f.missing {
+		return prices.Quote{}, prices.ErrNotFound
+	}
+	return prices.Quote{Cents: f.cents, Currency: currency}, nil
+}
+
+func TestCheckAll(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	var ids []int32
+	for _, titulo := range []string{"Hades", "Celeste"} {
+		game, err := store.CreateGame(ctx, datos.CreateGameParams{Titulo: titulo, Descripcion: "-", Categoria: "Indie", Estado: "deseado", Imagen: "-"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.UpsertPriceWatch(ctx, datos.UpsertPriceWatchParams{GameID: game.ID, TargetCents: 1000, Currency: "USD"}); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, game.ID)
+	}
+
+	w := prices.NewWatcher(fixed{cents: 999, missing: "Celeste"}, store, service.NewGames(store))
+	if err := w.CheckAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	observations, _ := store.ListPriceObservations(ctx, ids[0])
+	if len(observations) != 1 || observations[0].Source != "fixed" || observations[0].PriceCents != 999 {
+		t.Errorf("observations = %+v", observations)
+	}
+	if observations, _ := store.ListPriceObservations(ctx, ids[1]); len(observations) != 0 {
+		t.Errorf("a game the source does not know got %d observations", len(observations))
+	}
+	if count, _ := store.CountUnreadNotifications(ctx); count != 1 {
+		t.Errorf("unread = %d, want 1", count)
+	}
+}
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := prices.Fake{Now: func() time.Time { return day }}
+
+	a, _ := fake.Quote(ctx, "Hades", "EUR")
+	b, _ := fake.Quote(ctx, "Hades", "EUR")
+	if a != b || a.Currency != "EUR" || a.Cents <= 0 {
+		t.Errorf("quotes = %+v, %+v, want the same positive price", a, b)
+	}
+}
@@ -0,0 +1,1307 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	datos "tp-web/db/sqlc"
+
+	"github.com/lib/pq"
+)
+
+// Memory implementa Store guardando todo en memoria. Imita el
+// comportamiento de las queries de Postgres que importan a los handlers:
+// sql.ErrNoRows cuando no hay filas, el índice único sobre el título
+// normalizado y el formato de fecha de to_char.
+type Memory struct {
+	// txMu serializa las transacciones de RunInTx
+	txMu sync.Mutex
+
+	mu        sync.Mutex
+	nextID    int32
+	games     map[int32]datos.Game
+	revisions map[int32][]datos.GameRevision
+	nextTagID int32
+	tags      map[string]datos.Tag
+	gameTags  map[int32]map[int32]bool
+
+	nextPurchaseID int32
+	purchases      []datos.Purchase
+
+	priceWatches       map[int32]datos.PriceWatch
+	nextObservationID  int32
+	observations       []datos.PriceObservation
+	nextNotificationID int32
+	notifications      []datos.Notification
+
+	progress      map[int32]datos.GameProgress
+	nextSessionID int32
+	sessions      []datos.PlaySession
+
+	nextReviewID int32
+	reviews      []datos.Review
+
+	nextSeriesID  int32
+	series        map[int32]datos.Series
+	seriesEntries map[int32]datos.SeriesEntry
+	nextEditionID int32
+	editions      []datos.GameEdition
+}
+
+// NewMemory crea un repositorio en memoria vacío.
+func NewMemory() *Memory {
+	return &Memory{
+		games:     map[int32]datos.Game{},
+		revisions: map[int32][]datos.GameRevision{},
+		tags:      map[string]datos.Tag{},
+		gameTags:  map[int32]map[int32]bool{},
+
+		priceWatches: map[int32]datos.PriceWatch{},
+		progress:     map[int32]datos.GameProgress{},
+
+		series:        map[int32]datos.Series{},
+		seriesEntries: map[int32]datos.SeriesEntry{},
+	}
+}
+
+var _ Store = (*Memory)(nil)
+
+// RunInTx ejecuta fn y, si devuelve error, deja los datos como estaban antes.
+// Las transacciones se ejecutan de a una, pero no aíslan de las operaciones
+// hechas fuera de RunInTx mientras tanto.
+func (m *Memory) RunInTx(ctx context.Context, fn func(repo GameRepository) error) error {
+	m.txMu.Lock()
+	defer m.txMu.Unlock()
+
+	saved := m.snapshot()
+	if err := fn(m); err != nil {
+		m.restore(saved)
+		return err
+	}
+	return nil
+}
+
+// memoryData es una copia de los datos de Memory para deshacer una transacción.
+type memoryData struct {
+	nextID    int32
+	games     map[int32]datos.Game
+	revisions map[int32][]datos.GameRevision
+	nextTagID int32
+	tags      map[string]datos.Tag
+	gameTags  map[int32]map[int32]bool
+
+	nextPurchaseID int32
+	purchases      []datos.Purchase
+
+	priceWatches       map[int32]datos.PriceWatch
+	nextObservationID  int32
+	observations       []datos.PriceObservation
+	nextNotificationID int32
+	notifications      []datos.Notification
+
+	progress      map[int32]datos.GameProgress
+	nextSessionID int32
+	sessions      []datos.PlaySession
+
+	nextReviewID int32
+	reviews      []datos.Review
+
+	nextSeriesID  int32
+	series        map[int32]datos.Series
+	seriesEntries map[int32]datos.SeriesEntry
+	nextEditionID int32
+	editions      []datos.GameEdition
+}
+
+func (m *Memory) snapshot() memoryData {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	d := memoryData{
+		nextID:    m.nextID,
+		games:     maps.Clone(m.games),
+		revisions: map[int32][]datos.GameRevision{},
+		nextTagID: m.nextTagID,
+		tags:      maps.Clone(m.tags),
+		gameTags:  map[int32]map[int32]bool{},
+
+		nextPurchaseID: m.nextPurchaseID,
+		purchases:      slices.Clone(m.purchases),
+
+		priceWatches:       maps.Clone(m.priceWatches),
+		nextObservationID:  m.nextObservationID,
+		observations:       slices.Clone(m.observations),
+		nextNotificationID: m.nextNotificationID,
+		notifications:      slices.Clone(m.notifications),
+
+		progress:      maps.Clone(m.progress),
+		nextSessionID: m.nextSessionID,
+		sessions:      slices.Clone(m.sessions),
+
+		nextReviewID: m.nextReviewID,
+		reviews:      slices.Clone(m.reviews),
+
+		nextSeriesID:  m.nextSeriesID,
+		series:        maps.Clone(m.series),
+		seriesEntries: maps.Clone(m.seriesEntries),
+		nextEditionID: m.nextEditionID,
+		editions:      slices.Clone(m.editions),
+	}
+	for id, revs := range m.revisions {
+		d.revisions[id] = slices.Clone(revs)
+	}
+	for id, tags := range m.gameTags {
+		d.gameTags[id] = maps.Clone(tags)
+	}
+	return d
+}
+
+func (m *Memory) restore(d memoryData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID = d.nextID
+	m.games = d.games
+	m.revisions = d.revisions
+	m.nextTagID = d.nextTagID
+	m.tags = d.tags
+	m.gameTags = d.gameTags
+	m.nextPurchaseID = d.nextPurchaseID
+	m.purchases = d.purchases
+	m.priceWatches = d.priceWatches
+	m.nextObservationID = d.nextObservationID
+	m.observations = d.observations
+	m.nextNotificationID = d.nextNotificationID
+	m.notifications = d.notifications
+	m.progress = d.progress
+	m.nextSessionID = d.nextSessionID
+	m.sessions = d.sessions
+	m.nextReviewID = d.nextReviewID
+	m.reviews = d.reviews
+	m.nextSeriesID = d.nextSeriesID
+	m.series = d.series
+	m.seriesEntries = d.seriesEntries
+	m.nextEditionID = d.nextEditionID
+	m.editions = d.editions
+}
+
+func (m *Memory) AddGameTag(ctx context.Context, arg datos.AddGameTagParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return &pq.Error{Code: "23503", Constraint: "game_tags_game_id_fkey"}
+	}
+	if m.gameTags[arg.GameID] == nil {
+		m.gameTags[arg.GameID] = map[int32]bool{}
+	}
+	m.gameTags[arg.GameID][arg.TagID] = true
+	return nil
+}
+
+func (m *Memory) CountGamesAddedByMonth(ctx context.Context) ([]datos.CountGamesAddedByMonthRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := map[string]int64{}
+	for _, g := range m.games {
+		if g.CreatedAt.Valid {
+			totals[g.CreatedAt.Time.Format("2006-01")]++
+		}
+	}
+	var items []datos.CountGamesAddedByMonthRow
+	for month, total := range totals {
+		items = append(items, datos.CountGamesAddedByMonthRow{Month: month, Total: total})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Month < items[j].Month })
+	return items, nil
+}
+
+func (m *Memory) CountGamesByCategoria(ctx context.Context) ([]datos.CountGamesByCategoriaRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := map[string]int64{}
+	for _, g := range m.games {
+		totals[g.Categoria]++
+	}
+	var items []datos.CountGamesByCategoriaRow
+	for categoria, total := range totals {
+		items = append(items, datos.CountGamesByCategoriaRow{Categoria: categoria, Total: total})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Total != items[j].Total {
+			return items[i].Total > items[j].Total
+		}
+		return items[i].Categoria < items[j].Categoria
+	})
+	return items, nil
+}
+
+func (m *Memory) CountGamesByEstado(ctx context.Context) ([]datos.CountGamesByEstadoRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := map[string]int64{}
+	for _, g := range m.games {
+		totals[g.Estado]++
+	}
+	var items []datos.CountGamesByEstadoRow
+	for estado, total := range totals {
+		items = append(items, datos.CountGamesByEstadoRow{Estado: estado, Total: total})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Estado < items[j].Estado })
+	return items, nil
+}
+
+func (m *Memory) CountGamesByReleaseYear(ctx context.Context) ([]datos.CountGamesByReleaseYearRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := map[int32]int64{}
+	for _, g := range m.games {
+		if g.FechaPrecision != "tba" {
+			totals[int32(g.Fecha.Year())]++
+		}
+	}
+	var items []datos.CountGamesByReleaseYearRow
+	for year, total := range totals {
+		items = append(items, datos.CountGamesByReleaseYearRow{Year: year, Total: total})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Year < items[j].Year })
+	return items, nil
+}
+
+func (m *Memory) CountUnreadNotifications(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	for _, n := range m.notifications {
+		if !n.ReadAt.Valid {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *Memory) CreateGame(ctx context.Context, arg datos.CreateGameParams) (datos.CreateGameRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkUniqueTitle(0, arg.Plataforma, arg.Titulo); err != nil {
+		return datos.CreateGameRow{}, err
+	}
+
+	m.nextID++
+	g := datos.Game{
+		ID:             m.nextID,
+		Titulo:         arg.Titulo,
+		Descripcion:    arg.Descripcion,
+		Categoria:      arg.Categoria,
+		Plataforma:     arg.Plataforma,
+		Fecha:          arg.Fecha,
+		FechaPrecision: arg.FechaPrecision,
+		Estado:         arg.Estado,
+		Imagen:         arg.Imagen,
+		CreatedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.games[g.ID] = g
+	return datos.CreateGameRow(toRow(g)), nil
+}
+
+func (m *Memory) CreateGameRevision(ctx context.Context, arg datos.CreateGameRevisionParams) (datos.GameRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.GameRevision{}, &pq.Error{Code: "23503", Constraint: "game_revisions_game_id_fkey"}
+	}
+
+	revs := m.revisions[arg.GameID]
+	rev := datos.GameRevision{
+		ID:             int32(len(revs) + 1),
+		GameID:         arg.GameID,
+		Revision:       int32(len(revs) + 1),
+		Titulo:         arg.Titulo,
+		Descripcion:    arg.Descripcion,
+		Categoria:      arg.Categoria,
+		Plataforma:     arg.Plataforma,
+		Fecha:          arg.Fecha,
+		FechaPrecision: arg.FechaPrecision,
+		Estado:         arg.Estado,
+		Imagen:         arg.Imagen,
+		RevertedFrom:   arg.RevertedFrom,
+		CreatedAt:      sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.revisions[arg.GameID] = append(revs, rev)
+	return rev, nil
+}
+
+func (m *Memory) CreateNotification(ctx context.Context, arg datos.CreateNotificationParams) (datos.Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.Notification{}, &pq.Error{Code: "23503", Constraint: "notifications_game_id_fkey"}
+	}
+	m.nextNotificationID++
+	n := datos.Notification{
+		ID:         m.nextNotificationID,
+		GameID:     arg.GameID,
+		Kind:       arg.Kind,
+		PriceCents: arg.PriceCents,
+		Currency:   arg.Currency,
+		CreatedAt:  time.Now(),
+	}
+	m.notifications = append(m.notifications, n)
+	return n, nil
+}
+
+func (m *Memory) CreatePlaySession(ctx context.Context, arg datos.CreatePlaySessionParams) (datos.PlaySession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.PlaySession{}, &pq.Error{Code: "23503", Constraint: "play_sessions_game_id_fkey"}
+	}
+	if arg.EndedAt.Valid && arg.EndedAt.Time.Before(arg.StartedAt) {
+		return datos.PlaySession{}, &pq.Error{Code: "23514", Constraint: "play_sessions_ended_at_check"}
+	}
+	if !arg.EndedAt.Valid && m.openSession(arg.GameID) >= 0 {
+		return datos.PlaySession{}, &pq.Error{Code: "23505", Constraint: "play_sessions_open_key"}
+	}
+	m.nextSessionID++
+	s := datos.PlaySession{
+		ID:        m.nextSessionID,
+		GameID:    arg.GameID,
+		StartedAt: arg.StartedAt,
+		EndedAt:   arg.EndedAt,
+		Notes:     arg.Notes,
+	}
+	m.sessions = append(m.sessions, s)
+	return s, nil
+}
+
+func (m *Memory) CreatePriceObservation(ctx context.Context, arg datos.CreatePriceObservationParams) (datos.PriceObservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.PriceObservation{}, &pq.Error{Code: "23503", Constraint: "price_observations_game_id_fkey"}
+	}
+	m.nextObservationID++
+	o := datos.PriceObservation{
+		ID:         m.nextObservationID,
+		GameID:     arg.GameID,
+		PriceCents: arg.PriceCents,
+		Currency:   arg.Currency,
+		Source:     arg.Source,
+		ObservedAt: time.Now(),
+	}
+	m.observations = append(m.observations, o)
+	return o, nil
+}
+
+func (m *Memory) CreatePurchase(ctx context.Context, arg datos.CreatePurchaseParams) (datos.Purchase, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.Purchase{}, &pq.Error{Code: "23503", Constraint: "purchases_game_id_fkey"}
+	}
+	m.nextPurchaseID++
+	p := datos.Purchase{
+		ID:          m.nextPurchaseID,
+		GameID:      arg.GameID,
+		PriceCents:  arg.PriceCents,
+		Currency:    arg.Currency,
+		Store:       arg.Store,
+		PurchasedOn: arg.PurchasedOn,
+		Format:      arg.Format,
+		Notes:       arg.Notes,
+		CreatedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.purchases = append(m.purchases, p)
+	return p, nil
+}
+
+func (m *Memory) CreateSeries(ctx context.Context, name string) (datos.Series, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.series {
+		if s.Name == name {
+			return datos.Series{}, &pq.Error{Code: "23505", Constraint: "series_name_key"}
+		}
+	}
+	m.nextSeriesID++
+	s := datos.Series{
+		ID:        m.nextSeriesID,
+		Name:      name,
+		CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.series[s.ID] = s
+	return s, nil
+}
+
+func (m *Memory) DeleteGame(ctx context.Context, id int32) (datos.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[id]
+	if !ok {
+		return datos.Game{}, sql.ErrNoRows
+	}
+	delete(m.games, id)
+	delete(m.revisions, id)
+	delete(m.gameTags, id)
+	m.purchases = slices.DeleteFunc(m.purchases, func(p datos.Purchase) bool { return p.GameID == id })
+	delete(m.priceWatches, id)
+	m.observations = slices.DeleteFunc(m.observations, func(o datos.PriceObservation) bool { return o.GameID == id })
+	m.notifications = slices.DeleteFunc(m.notifications, func(n datos.Notification) bool { return n.GameID == id })
+	delete(m.progress, id)
+	m.sessions = slices.DeleteFunc(m.sessions, func(s datos.PlaySession) bool { return s.GameID == id })
+	m.reviews = slices.DeleteFunc(m.reviews, func(r datos.Review) bool { return r.GameID == id })
+	delete(m.seriesEntries, id)
+	m.editions = slices.DeleteFunc(m.editions, func(e datos.GameEdition) bool { return e.GameID == id })
+	return g, nil
+}
+
+func (m *Memory) DeleteGameEdition(ctx context.Context, arg datos.DeleteGameEditionParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.editions = slices.DeleteFunc(m.editions, func(e datos.GameEdition) bool { return e.GameID == arg.GameID && e.Edition == arg.Edition })
+	return nil
+}
+
+func (m *Memory) DeletePriceWatch(ctx context.Context, gameID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.priceWatches, gameID)
+	return nil
+}
+
+func (m *Memory) DeleteReview(ctx context.Context, arg datos.DeleteReviewParams) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reviews = slices.DeleteFunc(m.reviews, func(r datos.Review) bool { return r.ID == arg.ID && r.GameID == arg.GameID })
+	return nil
+}
+
+func (m *Memory) DeleteSeries(ctx context.Context, id int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.series, id)
+	maps.DeleteFunc(m.seriesEntries, func(_ int32, e datos.SeriesEntry) bool { return e.SeriesID == id })
+	return nil
+}
+
+func (m *Memory) DeleteSeriesEntry(ctx context.Context, gameID int32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.seriesEntries, gameID)
+	return nil
+}
+
+func (m *Memory) EndPlaySession(ctx context.Context, arg datos.EndPlaySessionParams) (datos.PlaySession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := m.openSession(arg.GameID)
+	if i < 0 {
+		return datos.PlaySession{}, sql.ErrNoRows
+	}
+	if arg.EndedAt.Valid && arg.EndedAt.Time.Before(m.sessions[i].StartedAt) {
+		return datos.PlaySession{}, &pq.Error{Code: "23514", Constraint: "play_sessions_ended_at_check"}
+	}
+	m.sessions[i].EndedAt = arg.EndedAt
+	return m.sessions[i], nil
+}
+
+func (m *Memory) GetGame(ctx context.Context, id int32) (datos.GetGameRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[id]
+	if !ok {
+		return datos.GetGameRow{}, sql.ErrNoRows
+	}
+	return datos.GetGameRow(toRow(g)), nil
+}
+
+func (m *Memory) GetGameProgress(ctx context.Context, gameID int32) (datos.GameProgress, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p, ok := m.progress[gameID]
+	if !ok {
+		return datos.GameProgress{}, sql.ErrNoRows
+	}
+	return p, nil
+}
+
+func (m *Memory) GetGameRevision(ctx context.Context, arg datos.GetGameRevisionParams) (datos.GameRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, rev := range m.revisions[arg.GameID] {
+		if rev.Revision == arg.Revision {
+			return rev, nil
+		}
+	}
+	return datos.GameRevision{}, sql.ErrNoRows
+}
+
+func (m *Memory) GetGameSeries(ctx context.Context, gameID int32) (datos.GetGameSeriesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.seriesEntries[gameID]
+	if !ok {
+		return datos.GetGameSeriesRow{}, sql.ErrNoRows
+	}
+	return datos.GetGameSeriesRow{ID: e.SeriesID, Name: m.series[e.SeriesID].Name, Position: e.Position}, nil
+}
+
+func (m *Memory) GetLatestPriceObservation(ctx context.Context, gameID int32) (datos.PriceObservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// observations está en orden de alta, que coincide con observed_at
+	for i := len(m.observations) - 1; i >= 0; i-- {
+		if m.observations[i].GameID == gameID {
+			return m.observations[i], nil
+		}
+	}
+	return datos.PriceObservation{}, sql.ErrNoRows
+}
+
+func (m *Memory) GetPriceWatch(ctx context.Context, gameID int32) (datos.PriceWatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.priceWatches[gameID]
+	if !ok {
+		return datos.PriceWatch{}, sql.ErrNoRows
+	}
+	return w, nil
+}
+
+func (m *Memory) GetSeries(ctx context.Context, id int32) (datos.Series, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.series[id]
+	if !ok {
+		return datos.Series{}, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+func (m *Memory) ListGameEditions(ctx context.Context, gameID int32) ([]datos.GameEdition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.GameEdition
+	for _, e := range m.editions {
+		if e.GameID == gameID {
+			items = append(items, e)
+		}
+	}
+	slices.SortFunc(items, func(a, b datos.GameEdition) int {
+		return slices.Index(editionOrder, a.Edition) - slices.Index(editionOrder, b.Edition)
+	})
+	return items, nil
+}
+
+func (m *Memory) ListGamePurchases(ctx context.Context, gameID int32) ([]datos.ListGamePurchasesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListGamePurchasesRow
+	for _, p := range m.purchases {
+		if p.GameID != gameID {
+			continue
+		}
+		items = append(items, datos.ListGamePurchasesRow{
+			ID:          p.ID,
+			GameID:      p.GameID,
+			PriceCents:  p.PriceCents,
+			Currency:    p.Currency,
+			Store:       p.Store,
+			PurchasedOn: p.PurchasedOn.Format("2006-01-02"),
+			Format:      p.Format,
+			Notes:       p.Notes,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].PurchasedOn != items[j].PurchasedOn {
+			return items[i].PurchasedOn > items[j].PurchasedOn
+		}
+		return items[i].ID > items[j].ID
+	})
+	return items, nil
+}
+
+func (m *Memory) ListGameReviews(ctx context.Context, gameID int32) ([]datos.Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.Review
+	for _, r := range m.reviews {
+		if r.GameID == gameID {
+			items = append(items, r)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].UpdatedAt.Time.Equal(items[j].UpdatedAt.Time) {
+			return items[i].UpdatedAt.Time.After(items[j].UpdatedAt.Time)
+		}
+		return items[i].ID > items[j].ID
+	})
+	return items, nil
+}
+
+func (m *Memory) ListGameRevisions(ctx context.Context, gameID int32) ([]datos.ListGameRevisionsRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revs := m.revisions[gameID]
+	items := make([]datos.ListGameRevisionsRow, 0, len(revs))
+	for i := len(revs) - 1; i >= 0; i-- {
+		rev := revs[i]
+		items = append(items, datos.ListGameRevisionsRow{
+			ID:             rev.ID,
+			GameID:         rev.GameID,
+			Revision:       rev.Revision,
+			Titulo:         rev.Titulo,
+			Descripcion:    rev.Descripcion,
+			Categoria:      rev.Categoria,
+			Plataforma:     rev.Plataforma,
+			Fecha:          rev.Fecha.Format("2006-01-02"),
+			FechaPrecision: rev.FechaPrecision,
+			Estado:         rev.Estado,
+			Imagen:         rev.Imagen,
+			RevertedFrom:   rev.RevertedFrom,
+			CreatedAt:      rev.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+func (m *Memory) ListGameTags(ctx context.Context, gameID int32) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var names []string
+	for _, tag := range m.tags {
+		if m.gameTags[gameID][tag.ID] {
+			names = append(names, tag.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *Memory) ListGames(ctx context.Context) ([]datos.ListGamesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListGamesRow
+	for _, g := range m.sortedGames() {
+		row := toRow(g)
+		item := datos.ListGamesRow{
+			ID:             row.ID,
+			Titulo:         row.Titulo,
+			Descripcion:    row.Descripcion,
+			Categoria:      row.Categoria,
+			Plataforma:     row.Plataforma,
+			Fecha:          row.Fecha,
+			FechaPrecision: row.FechaPrecision,
+			Estado:         row.Estado,
+			Imagen:         row.Imagen,
+			CreatedAt:      row.CreatedAt,
+		}
+		var sum int32
+		for _, r := range m.reviews {
+			if r.GameID == g.ID {
+				sum += r.Rating
+				item.ReviewCount++
+			}
+		}
+		if item.ReviewCount > 0 {
+			item.AvgRating = float64(sum) / float64(item.ReviewCount)
+		}
+		if e, ok := m.seriesEntries[g.ID]; ok {
+			item.SeriesID = e.SeriesID
+			item.SeriesName = m.series[e.SeriesID].Name
+			item.SeriesPosition = e.Position
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (m *Memory) ListNotifications(ctx context.Context) ([]datos.ListNotificationsRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListNotificationsRow
+	for i := len(m.notifications) - 1; i >= 0 && len(items) < 50; i-- {
+		n := m.notifications[i]
+		items = append(items, datos.ListNotificationsRow{
+			ID:         n.ID,
+			GameID:     n.GameID,
+			Titulo:     m.games[n.GameID].Titulo,
+			Kind:       n.Kind,
+			PriceCents: n.PriceCents,
+			Currency:   n.Currency,
+			ReadAt:     n.ReadAt,
+			CreatedAt:  n.CreatedAt,
+		})
+	}
+	return items, nil
+}
+
+func (m *Memory) ListPlaySessions(ctx context.Context, gameID int32) ([]datos.PlaySession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.PlaySession
+	for _, s := range m.sessions {
+		if s.GameID == gameID {
+			items = append(items, s)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].StartedAt.Equal(items[j].StartedAt) {
+			return items[i].StartedAt.After(items[j].StartedAt)
+		}
+		return items[i].ID > items[j].ID
+	})
+	return items, nil
+}
+
+func (m *Memory) ListPlayingGames(ctx context.Context) ([]datos.ListPlayingGamesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListPlayingGamesRow
+	lastPlayed := map[int32]time.Time{}
+	for _, g := range m.sortedGames() {
+		if m.progress[g.ID].PlayStatus != "playing" {
+			continue
+		}
+		row := datos.ListPlayingGamesRow{ID: g.ID, Titulo: g.Titulo, Imagen: g.Imagen}
+		var played time.Duration
+		for _, s := range m.sessions {
+			if s.GameID != g.ID {
+				continue
+			}
+			if s.EndedAt.Valid {
+				played += s.EndedAt.Time.Sub(s.StartedAt)
+			} else {
+				row.InSession = true
+			}
+			if s.StartedAt.After(lastPlayed[g.ID]) {
+				lastPlayed[g.ID] = s.StartedAt
+			}
+		}
+		row.PlayedSeconds = int64(played.Round(time.Second).Seconds())
+		items = append(items, row)
+	}
+	// Los que tienen sesiones primero, del jugado más recientemente; el resto
+	// queda por título
+	sort.SliceStable(items, func(i, j int) bool {
+		return lastPlayed[items[i].ID].After(lastPlayed[items[j].ID])
+	})
+	return items, nil
+}
+
+func (m *Memory) ListPriceObservations(ctx context.Context, gameID int32) ([]datos.PriceObservation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.PriceObservation
+	for _, o := range m.observations {
+		if o.GameID == gameID {
+			items = append(items, o)
+		}
+	}
+	return items, nil
+}
+
+func (m *Memory) ListPriceWatches(ctx context.Context) ([]datos.ListPriceWatchesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListPriceWatchesRow
+	for _, g := range m.sortedGames() {
+		w, ok := m.priceWatches[g.ID]
+		if !ok || g.Estado != "deseado" {
+			continue
+		}
+		items = append(items, datos.ListPriceWatchesRow{GameID: g.ID, Titulo: g.Titulo, TargetCents: w.TargetCents, Currency: w.Currency})
+	}
+	return items, nil
+}
+
+func (m *Memory) ListReleasesBetween(ctx context.Context, arg datos.ListReleasesBetweenParams) ([]datos.ListReleasesBetweenRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	games := m.sortedGames()
+	sort.SliceStable(games, func(i, j int) bool { return games[i].Fecha.Before(games[j].Fecha) })
+	var items []datos.ListReleasesBetweenRow
+	for _, g := range games {
+		if g.FechaPrecision == "day" && !g.Fecha.Before(arg.FromDate) && !g.Fecha.After(arg.ToDate) {
+			items = append(items, datos.ListReleasesBetweenRow(toRow(g)))
+		}
+	}
+	return items, nil
+}
+
+func (m *Memory) ListSeries(ctx context.Context) ([]datos.ListSeriesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListSeriesRow
+	for _, s := range m.series {
+		item := datos.ListSeriesRow{ID: s.ID, Name: s.Name}
+		for _, e := range m.seriesEntries {
+			if e.SeriesID == s.ID {
+				item.Entries++
+				if m.owned(e.GameID) {
+					item.Owned++
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func (m *Memory) ListSeriesEntries(ctx context.Context, seriesID int32) ([]datos.ListSeriesEntriesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListSeriesEntriesRow
+	for _, e := range m.seriesEntries {
+		if e.SeriesID != seriesID {
+			continue
+		}
+		row := toRow(m.games[e.GameID])
+		items = append(items, datos.ListSeriesEntriesRow{
+			ID:             row.ID,
+			Titulo:         row.Titulo,
+			Fecha:          row.Fecha,
+			FechaPrecision: row.FechaPrecision,
+			Estado:         row.Estado,
+			Imagen:         row.Imagen,
+			Position:       e.Position,
+			Owned:          m.owned(e.GameID),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].Titulo < items[j].Titulo
+	})
+	return items, nil
+}
+
+func (m *Memory) ListSimilarGames(ctx context.Context, titulo string) ([]datos.ListSimilarGamesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListSimilarGamesRow
+	for _, g := range m.sortedGames() {
+		// 0.3 es el umbral por defecto del operador % de pg_trgm
+		if score := similarity(g.Titulo, titulo); score >= 0.3 {
+			items = append(items, datos.ListSimilarGamesRow{ID: g.ID, Titulo: g.Titulo, Plataforma: g.Plataforma, Score: score})
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Score > items[j].Score })
+	if len(items) > 5 {
+		items = items[:5]
+	}
+	return items, nil
+}
+
+func (m *Memory) ListWantedGames(ctx context.Context) ([]datos.ListWantedGamesRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var items []datos.ListWantedGamesRow
+	for _, g := range m.sortedGames() {
+		if g.Estado == "deseado" {
+			items = append(items, datos.ListWantedGamesRow(toRow(g)))
+		}
+	}
+	return items, nil
+}
+
+func (m *Memory) MarkNotificationsRead(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	for i := range m.notifications {
+		if !m.notifications[i].ReadAt.Valid {
+			m.notifications[i].ReadAt = now
+		}
+	}
+	return nil
+}
+
+func (m *Memory) SetSeriesEntry(ctx context.Context, arg datos.SetSeriesEntryParams) (datos.SeriesEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.SeriesEntry{}, &pq.Error{Code: "23503", Constraint: "series_entries_game_id_fkey"}
+	}
+	if _, ok := m.series[arg.SeriesID]; !ok {
+		return datos.SeriesEntry{}, &pq.Error{Code: "23503", Constraint: "series_entries_series_id_fkey"}
+	}
+	if arg.Position <= 0 {
+		return datos.SeriesEntry{}, &pq.Error{Code: "23514", Constraint: "series_entries_position_check"}
+	}
+	e := datos.SeriesEntry(arg)
+	m.seriesEntries[arg.GameID] = e
+	return e, nil
+}
+
+func (m *Memory) SpendByCategoria(ctx context.Context) ([]datos.SpendByCategoriaRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type key struct{ categoria, currency string }
+	totals := map[key]int64{}
+	for _, p := range m.purchases {
+		totals[key{m.games[p.GameID].Categoria, p.Currency}] += int64(p.PriceCents)
+	}
+	var items []datos.SpendByCategoriaRow
+	for k, total := range totals {
+		items = append(items, datos.SpendByCategoriaRow{Categoria: k.categoria, Currency: k.currency, TotalCents: total})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.TotalCents != b.TotalCents {
+			return a.TotalCents > b.TotalCents
+		}
+		if a.Categoria != b.Categoria {
+			return a.Categoria < b.Categoria
+		}
+		return a.Currency < b.Currency
+	})
+	return items, nil
+}
+
+func (m *Memory) SpendByMonth(ctx context.Context) ([]datos.SpendByMonthRow, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	type key struct{ month, currency string }
+	totals := map[key]int64{}
+	for _, p := range m.purchases {
+		totals[key{p.PurchasedOn.Format("2006-01"), p.Currency}] += int64(p.PriceCents)
+	}
+	var items []datos.SpendByMonthRow
+	for k, total := range totals {
+		items = append(items, datos.SpendByMonthRow{Month: k.month, Currency: k.currency, TotalCents: total})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Month != items[j].Month {
+			return items[i].Month < items[j].Month
+		}
+		return items[i].Currency < items[j].Currency
+	})
+	return items, nil
+}
+
+func (m *Memory) UpdateGame(ctx context.Context, arg datos.UpdateGameParams) (datos.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[arg.ID]
+	if !ok {
+		return datos.Game{}, sql.ErrNoRows
+	}
+	if err := m.checkUniqueTitle(arg.ID, arg.Plataforma, arg.Titulo); err != nil {
+		return datos.Game{}, err
+	}
+	g.Titulo = arg.Titulo
+	g.Descripcion = arg.Descripcion
+	g.Categoria = arg.Categoria
+	g.Plataforma = arg.Plataforma
+	g.Fecha = arg.Fecha
+	g.FechaPrecision = arg.FechaPrecision
+	g.Estado = arg.Estado
+	g.Imagen = arg.Imagen
+	m.games[g.ID] = g
+	return g, nil
+}
+
+func (m *Memory) UpdateGameCategory(ctx context.Context, arg datos.UpdateGameCategoryParams) (datos.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[arg.ID]
+	if !ok {
+		return datos.Game{}, sql.ErrNoRows
+	}
+	g.Categoria = arg.Categoria
+	m.games[g.ID] = g
+	return g, nil
+}
+
+func (m *Memory) UpdateGameState(ctx context.Context, arg datos.UpdateGameStateParams) (datos.Game, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	g, ok := m.games[arg.ID]
+	if !ok {
+		return datos.Game{}, sql.ErrNoRows
+	}
+	g.Estado = arg.Estado
+	m.games[g.ID] = g
+	return g, nil
+}
+
+func (m *Memory) UpsertGameEdition(ctx context.Context, arg datos.UpsertGameEditionParams) (datos.GameEdition, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.GameEdition{}, &pq.Error{Code: "23503", Constraint: "game_editions_game_id_fkey"}
+	}
+	if !slices.Contains(editionOrder, arg.Edition) {
+		return datos.GameEdition{}, &pq.Error{Code: "23514", Constraint: "game_editions_edition_check"}
+	}
+	for i, e := range m.editions {
+		if e.GameID == arg.GameID && e.Edition == arg.Edition {
+			m.editions[i].Owned = arg.Owned
+			return m.editions[i], nil
+		}
+	}
+	m.nextEditionID++
+	e := datos.GameEdition{
+		ID:        m.nextEditionID,
+		GameID:    arg.GameID,
+		Edition:   arg.Edition,
+		Owned:     arg.Owned,
+		CreatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.editions = append(m.editions, e)
+	return e, nil
+}
+
+func (m *Memory) UpsertGameProgress(ctx context.Context, arg datos.UpsertGameProgressParams) (datos.GameProgress, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.GameProgress{}, &pq.Error{Code: "23503", Constraint: "game_progress_game_id_fkey"}
+	}
+	p := datos.GameProgress{
+		GameID:      arg.GameID,
+		PlayStatus:  arg.PlayStatus,
+		CompletedOn: arg.CompletedOn,
+		UpdatedAt:   sql.NullTime{Time: time.Now(), Valid: true},
+	}
+	m.progress[arg.GameID] = p
+	return p, nil
+}
+
+func (m *Memory) UpsertPriceWatch(ctx context.Context, arg datos.UpsertPriceWatchParams) (datos.PriceWatch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.PriceWatch{}, &pq.Error{Code: "23503", Constraint: "price_watches_game_id_fkey"}
+	}
+	w, ok := m.priceWatches[arg.GameID]
+	if !ok {
+		w = datos.PriceWatch{GameID: arg.GameID, CreatedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	}
+	w.TargetCents = arg.TargetCents
+	w.Currency = arg.Currency
+	m.priceWatches[arg.GameID] = w
+	return w, nil
+}
+
+func (m *Memory) UpsertReview(ctx context.Context, arg datos.UpsertReviewParams) (datos.Review, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.games[arg.GameID]; !ok {
+		return datos.Review{}, &pq.Error{Code: "23503", Constraint: "reviews_game_id_fkey"}
+	}
+	if arg.Rating < 1 || arg.Rating > 10 {
+		return datos.Review{}, &pq.Error{Code: "23514", Constraint: "reviews_rating_check"}
+	}
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+	for i, r := range m.reviews {
+		if r.GameID == arg.GameID && r.Reviewer == arg.Reviewer {
+			m.reviews[i].Rating = arg.Rating
+			m.reviews[i].Body = arg.Body
+			m.reviews[i].UpdatedAt = now
+			return m.reviews[i], nil
+		}
+	}
+	m.nextReviewID++
+	r := datos.Review{
+		ID:        m.nextReviewID,
+		GameID:    arg.GameID,
+		Reviewer:  arg.Reviewer,
+		Rating:    arg.Rating,
+		Body:      arg.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	m.reviews = append(m.reviews, r)
+	return r, nil
+}
+
+func (m *Memory) UpsertTag(ctx context.Context, name string) (datos.Tag, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tag, ok := m.tags[name]; ok {
+		return tag, nil
+	}
+	m.nextTagID++
+	tag := datos.Tag{ID: m.nextTagID, Name: name}
+	m.tags[name] = tag
+	return tag, nil
+}
+
+// sortedGames devuelve los juegos ordenados por título, como ORDER BY titulo.
+func (m *Memory) sortedGames() []datos.Game {
+	games := make([]datos.Game, 0, len(m.games))
+	for _, g := range m.games {
+		games = append(games, g)
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].Titulo < games[j].Titulo })
+	return games
+}
+
+// checkUniqueTitle reproduce el índice único games_plataforma_titulo_key.
+func (m *Memory) checkUniqueTitle(id int32, plataforma, titulo string) error {
+	for _, g := range m.games {
+		if g.ID != id && g.Plataforma == plataforma && normalizeTitle(g.Titulo) == normalizeTitle(titulo) {
+			return &pq.Error{Code: "23505", Constraint: "games_plataforma_titulo_key"}
+		}
+	}
+	return nil
+}
+
+// openSession devuelve el índice de la sesión en curso del juego, o -1 si no
+// tiene; reproduce el índice único parcial play_sessions_open_key.
+func (m *Memory) openSession(gameID int32) int {
+	for i, s := range m.sessions {
+		if s.GameID == gameID && !s.EndedAt.Valid {
+			return i
+		}
+	}
+	return -1
+}
+
+// owned reproduce la condición de ListSeries: el juego está comprado o se
+// tiene alguna de sus ediciones.
+func (m *Memory) owned(gameID int32) bool {
+	if m.games[gameID].Estado == "comprado" {
+		return true
+	}
+	return slices.ContainsFunc(m.editions, func(e datos.GameEdition) bool { return e.GameID == gameID && e.Owned })
+}
+
+// editionOrder es el orden de las ediciones en ListGameEditions y los
+// valores que admite el CHECK de game_editions.edition.
+var editionOrder = []string{"standard", "deluxe", "goty", "ultimate", "collector"}
+
+// gameRow tiene la forma de las filas que devuelven las queries con to_char(fecha).
+type gameRow struct {
+	ID             int32        `json:"id"`
+	Titulo         string       `json:"titulo"`
+	Descripcion    string       `json:"descripcion"`
+	Categoria      string       `json:"categoria"`
+	Plataforma     string       `json:"plataforma"`
+	Fecha          string       `json:"fecha"`
+	FechaPrecision string       `json:"fecha_precision"`
+	Estado         string       `json:"estado"`
+	Imagen         string       `json:"imagen"`
+	CreatedAt      sql.NullTime `json:"created_at"`
+}
+
+func toRow(g datos.Game) gameRow {
+	return gameRow{
+		ID:             g.ID,
+		Titulo:         g.Titulo,
+		Descripcion:    g.Descripcion,
+		Categoria:      g.Categoria,
+		Plataforma:     g.Plataforma,
+		Fecha:          g.Fecha.Format("2006-01-02"),
+		FechaPrecision: g.FechaPrecision,
+		Estado:         g.Estado,
+		Imagen:         g.Imagen,
+		CreatedAt:      g.CreatedAt,
+	}
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u",
+	"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u",
+	"ä", "a", "ë", "e", "ï", "i", "ö", "o", "ü", "u",
+	"â", "a", "ê", "e", "î", "i", "ô", "o", "û", "u",
+	"ñ", "n", "ç", "c",
+)
+
+// normalizeTitle es el equivalente a la función normalize_title de la base.
+func normalizeTitle(titulo string) string {
+	return strings.Join(strings.Fields(accents.Replace(strings.ToLower(titulo))), " ")
+}
+
+// similarity calcula la similitud por trigramas igual que pg_trgm: cada
+// palabra se rellena con dos espacios adelante y uno atrás.
+func similarity(a, b string) float64 {
+	ta, tb := trigrams(normalizeTitle(a)), trigrams(normalizeTitle(b))
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
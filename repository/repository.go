@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	db_connect "tp-web/db"
+	datos "tp-web/db/sqlc"
+)
+
+// GameRepository es el acceso a los datos de juegos que usan los handlers.
+// Refleja los métodos generados por sqlc para poder cambiar Postgres por
+// otra implementación (por ejemplo la de memoria en los tests).
+type GameRepository interface {
+	AddGameTag(ctx context.Context, arg datos.AddGameTagParams) error
+	CountGamesAddedByMonth(ctx context.Context) ([]datos.CountGamesAddedByMonthRow, error)
+	CountGamesByCategoria(ctx context.Context) ([]datos.CountGamesByCategoriaRow, error)
+	CountGamesByEstado(ctx context.Context) ([]datos.CountGamesByEstadoRow, error)
+	CountGamesByReleaseYear(ctx context.Context) ([]datos.CountGamesByReleaseYearRow, error)
+	CountUnreadNotifications(ctx context.Context) (int64, error)
+	CreateGame(ctx context.Context, arg datos.CreateGameParams) (datos.CreateGameRow, error)
+	CreateGameRevision(ctx context.Context, arg datos.CreateGameRevisionParams) (datos.GameRevision, error)
+	CreateNotification(ctx context.Context, arg datos.CreateNotificationParams) (datos.Notification, error)
+	CreatePlaySession(ctx context.Context, arg datos.CreatePlaySessionParams) (datos.PlaySession, error)
+	CreatePriceObservation(ctx context.Context, arg datos.CreatePriceObservationParams) (datos.PriceObservation, error)
+	CreatePurchase(ctx context.Context, arg datos.CreatePurchaseParams) (datos.Purchase, error)
+	CreateSeries(ctx context.Context, name string) (datos.Series, error)
+	DeleteGame(ctx context.Context, id int32) (datos.Game, error)
+	DeleteGameEdition(ctx context.Context, arg datos.DeleteGameEditionParams) error
+	DeletePriceWatch(ctx context.Context, gameID int32) error
+	DeleteReview(ctx context.Context, arg datos.DeleteReviewParams) error
+	DeleteSeries(ctx context.Context, id int32) error
+	DeleteSeriesEntry(ctx context.Context, gameID int32) error
+	EndPlaySession(ctx context.Context, arg datos.EndPlaySessionParams) (datos.PlaySession, error)
+	GetGame(ctx context.Context, id int32) (datos.GetGameRow, error)
+	GetGameProgress(ctx context.Context, gameID int32) (datos.GameProgress, error)
+	GetGameRevision(ctx context.Context, arg datos.GetGameRevisionParams) (datos.GameRevision, error)
+	GetGameSeries(ctx context.Context, gameID int32) (datos.GetGameSeriesRow, error)
+	GetLatestPriceObservation(ctx context.Context, gameID int32) (datos.PriceObservation, error)
+	GetPriceWatch(ctx context.Context, gameID int32) (datos.PriceWatch, error)
+	GetSeries(ctx context.Context, id int32) (datos.Series, error)
+	ListGameEditions(ctx context.Context, gameID int32) ([]datos.GameEdition, error)
+	ListGamePurchases(ctx context.Context, gameID int32) ([]datos.ListGamePurchasesRow, error)
+	ListGameReviews(ctx context.Context, gameID int32) ([]datos.Review, error)
+	ListGameRevisions(ctx context.Context, gameID int32) ([]datos.ListGameRevisionsRow, error)
+	ListGameTags(ctx context.Context, gameID int32) ([]string, error)
+	ListGames(ctx context.Context) ([]datos.ListGamesRow, error)
+	ListNotifications(ctx context.Context) ([]datos.ListNotificationsRow, error)
+	ListPlaySessions(ctx context.Context, gameID int32) ([]datos.PlaySession, error)
+	ListPlayingGames(ctx context.Context) ([]datos.ListPlayingGamesRow, error)
+	ListPriceObservations(ctx context.Context, gameID int32) ([]datos.PriceObservation, error)
+	ListPriceWatches(ctx context.Context) ([]datos.ListPriceWatchesRow, error)
+	ListReleasesBetween(ctx context.Context, arg datos.ListReleasesBetweenParams) ([]datos.ListReleasesBetweenRow, error)
+	ListSeries(ctx context.Context) ([]datos.ListSeriesRow, error)
+	ListSeriesEntries(ctx context.Context, seriesID int32) ([]datos.ListSeriesEntriesRow, error)
+	ListSimilarGames(ctx context.Context, titulo string) ([]datos.ListSimilarGamesRow, error)
+	ListWantedGames(ctx context.Context) ([]datos.ListWantedGamesRow, error)
+	MarkNotificationsRead(ctx context.Context) error
+	SetSeriesEntry(ctx context.Context, arg datos.SetSeriesEntryParams) (datos.SeriesEntry, error)
+	SpendByCategoria(ctx context.Context) ([]datos.SpendByCategoriaRow, error)
+	SpendByMonth(ctx context.Context) ([]datos.SpendByMonthRow, error)
+	UpdateGame(ctx context.Context, arg datos.UpdateGameParams) (datos.Game, error)
+	UpdateGameCategory(ctx context.Context, arg datos.UpdateGameCategoryParams) (datos.Game, error)
+	UpdateGameState(ctx context.Context, arg datos.UpdateGameStateParams) (datos.Game, error)
+	UpsertGameEdition(ctx context.Context, arg datos.UpsertGameEditionParams) (datos.GameEdition, error)
+	UpsertGameProgress(ctx context.Context, arg datos.UpsertGameProgressParams) (datos.GameProgress, error)
+	UpsertPriceWatch(ctx context.Context, arg datos.UpsertPriceWatchParams) (datos.PriceWatch, error)
+	UpsertReview(ctx context.Context, arg datos.UpsertReviewParams) (datos.Review, error)
+	UpsertTag(ctx context.Context, name string) (datos.Tag, error)
+}
+
+// Store es un GameRepository que además puede ejecutar varias operaciones
+// en una transacción.
+type Store interface {
+	GameRepository
+
+	// RunInTx ejecuta fn con un repositorio ligado a una transacción: si fn
+	// devuelve error no queda nada de lo que hizo. fn puede ejecutarse más de
+	// una vez si la transacción choca con otra.
+	RunInTx(ctx context.Context, fn func(repo GameRepository) error) error
+}
+
+// Postgres implementa Store con las queries generadas por sqlc.
+type Postgres struct {
+	*datos.Queries
+
+	// db es el pool para abrir transacciones; nil si el repositorio ya
+	// trabaja sobre una.
+	db   *sql.DB
+	wrap func(datos.DBTX) datos.DBTX
+}
+
+// NewPostgres crea el repositorio sobre una conexión (o transacción) a Postgres.
+func NewPostgres(db datos.DBTX) *Postgres {
+	return &Postgres{Queries: datos.New(db)}
+}
+
+// NewPostgresDB crea el repositorio sobre el pool db. wrap, si no es nil,
+// envuelve la conexión y cada transacción (por ejemplo con tracing.WrapDB).
+func NewPostgresDB(db *sql.DB, wrap func(datos.DBTX) datos.DBTX) *Postgres {
+	if wrap == nil {
+		wrap = func(db datos.DBTX) datos.DBTX { return db }
+	}
+	return &Postgres{Queries: datos.New(wrap(db)), db: db, wrap: wrap}
+}
+
+func (p *Postgres) RunInTx(ctx context.Context, fn func(repo GameRepository) error) error {
+	if p.db == nil {
+		// Ya estamos dentro de una transacción (o de una conexión suelta)
+		return fn(p)
+	}
+	return db_connect.RunInTx(ctx, p.db, func(tx *sql.Tx) error {
+		return fn(&Postgres{Queries: datos.New(p.wrap(tx))})
+	})
+}
+
+var _ Store = (*Postgres)(nil)
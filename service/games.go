@@ -0,0 +1,317 @@
+// Package service agrupa las operaciones sobre juegos que tocan varias
+// tablas y tienen que hacerse completas o no hacerse: cada una corre en una
+// transacción con Store.RunInTx.
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/repository"
+	"tp-web/validation"
+)
+
+// Games implementa las operaciones de varios pasos sobre juegos.
+type Games struct {
+	store repository.Store
+}
+
+func NewGames(store repository.Store) *Games {
+	return &Games{store: store}
+}
+
+// CreateGame da de alta un juego con sus etiquetas y registra la revisión
+// inicial de su historial, todo en una transacción.
+func (s *Games) CreateGame(ctx context.Context, arg datos.CreateGameParams, tags []string) (datos.CreateGameRow, error) {
+	var game datos.CreateGameRow
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		var err error
+		game, err = repo.CreateGame(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if err := addTags(ctx, repo, game.ID, tags); err != nil {
+			return err
+		}
+		_, err = repo.CreateGameRevision(ctx, datos.CreateGameRevisionParams{
+			GameID:         game.ID,
+			Titulo:         arg.Titulo,
+			Descripcion:    arg.Descripcion,
+			Categoria:      arg.Categoria,
+			Plataforma:     arg.Plataforma,
+			Fecha:          arg.Fecha,
+			FechaPrecision: arg.FechaPrecision,
+			Estado:         arg.Estado,
+			Imagen:         arg.Imagen,
+		})
+		if err != nil {
+			return fmt.Errorf("revisión inicial: %w", err)
+		}
+		return nil
+	})
+	return game, err
+}
+
+// RevertGame vuelve un juego a los datos de una revisión anterior. La
+// reversión no reescribe el historial: se guarda como una revisión nueva.
+func (s *Games) RevertGame(ctx context.Context, gameID, revision int32) (datos.GameRevision, error) {
+	var created datos.GameRevision
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		target, err := repo.GetGameRevision(ctx, datos.GetGameRevisionParams{
+			GameID:   gameID,
+			Revision: revision,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = repo.UpdateGame(ctx, datos.UpdateGameParams{
+			ID:             target.GameID,
+			Titulo:         target.Titulo,
+			Descripcion:    target.Descripcion,
+			Categoria:      target.Categoria,
+			Plataforma:     target.Plataforma,
+			Fecha:          target.Fecha,
+			FechaPrecision: target.FechaPrecision,
+			Estado:         target.Estado,
+			Imagen:         target.Imagen,
+		})
+		if err != nil {
+			return err
+		}
+
+		created, err = repo.CreateGameRevision(ctx, datos.CreateGameRevisionParams{
+			GameID:         target.GameID,
+			Titulo:         target.Titulo,
+			Descripcion:    target.Descripcion,
+			Categoria:      target.Categoria,
+			Plataforma:     target.Plataforma,
+			Fecha:          target.Fecha,
+			FechaPrecision: target.FechaPrecision,
+			Estado:         target.Estado,
+			Imagen:         target.Imagen,
+			RevertedFrom:   sql.NullInt32{Int32: target.Revision, Valid: true},
+		})
+		return err
+	})
+	return created, err
+}
+
+// RecordPurchase registra una compra del juego y, si todavía no estaba
+// comprado, lo pasa a "comprado" guardando el cambio como revisión.
+func (s *Games) RecordPurchase(ctx context.Context, arg datos.CreatePurchaseParams) (datos.Purchase, error) {
+	var purchase datos.Purchase
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		game, err := repo.GetGame(ctx, arg.GameID)
+		if err != nil {
+			return err
+		}
+		purchase, err = repo.CreatePurchase(ctx, arg)
+		if err != nil {
+			return err
+		}
+		if game.Estado == "comprado" {
+			return nil
+		}
+		return updateWithRevision(ctx, repo, func() (datos.Game, error) {
+			return repo.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: arg.GameID, Estado: "comprado"})
+		})
+	})
+	return purchase, err
+}
+
+// NotificationPriceBelowTarget es el tipo de aviso que se genera cuando un
+// juego deseado llega a su precio objetivo.
+const NotificationPriceBelowTarget = "price_below_target"
+
+// RecordPrice guarda un precio observado del juego. Si el juego está deseado
+// y el precio llega o baja del objetivo (en la misma moneda), genera un aviso;
+// solo la primera vez que cruza el objetivo, no en cada observación
+// siguiente. Devuelve si se generó el aviso.
+func (s *Games) RecordPrice(ctx context.Context, arg datos.CreatePriceObservationParams) (datos.PriceObservation, bool, error) {
+	var (
+		observation datos.PriceObservation
+		notified    bool
+	)
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		notified = false
+		game, err := repo.GetGame(ctx, arg.GameID)
+		if err != nil {
+			return err
+		}
+		previous, err := repo.GetLatestPriceObservation(ctx, arg.GameID)
+		hasPrevious := err == nil
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		observation, err = repo.CreatePriceObservation(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		watch, err := repo.GetPriceWatch(ctx, arg.GameID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		reached := func(o datos.PriceObservation) bool {
+			return o.Currency == watch.Currency && o.PriceCents <= watch.TargetCents
+		}
+		if game.Estado != "deseado" || !reached(observation) || (hasPrevious && reached(previous)) {
+			return nil
+		}
+
+		_, err = repo.CreateNotification(ctx, datos.CreateNotificationParams{
+			GameID:     arg.GameID,
+			Kind:       NotificationPriceBelowTarget,
+			PriceCents: observation.PriceCents,
+			Currency:   observation.Currency,
+		})
+		notified = err == nil
+		return err
+	})
+	return observation, notified, err
+}
+
+// BulkResult resume una acción masiva: cuántos juegos se pidieron y a
+// cuántos se les aplicó (los que ya no existen se saltean).
+type BulkResult struct {
+	Action    string `json:"action"`
+	Requested int    `json:"requested"`
+	Affected  int    `json:"affected"`
+}
+
+// Bulk aplica una acción ya validada a varios juegos en una transacción: si
+// falla con alguno no se modifica ninguno. Los cambios de estado y de
+// categoría quedan registrados como revisiones.
+func (s *Games) Bulk(ctx context.Context, in validation.BulkInput) (BulkResult, error) {
+	result := BulkResult{Action: in.Action, Requested: len(in.IDs)}
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		result.Affected = 0
+		for _, id := range in.IDs {
+			// Un juego borrado mientras tanto no es un error; en Postgres
+			// además no se puede seguir usando la transacción después de
+			// una violación de clave foránea, así que se revisa antes.
+			if _, err := repo.GetGame(ctx, id); errors.Is(err, sql.ErrNoRows) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			var err error
+			switch in.Action {
+			case validation.BulkDelete:
+				_, err = repo.DeleteGame(ctx, id)
+			case validation.BulkSetState:
+				err = updateWithRevision(ctx, repo, func() (datos.Game, error) {
+					return repo.UpdateGameState(ctx, datos.UpdateGameStateParams{ID: id, Estado: in.Estado})
+				})
+			case validation.BulkSetCategory:
+				err = updateWithRevision(ctx, repo, func() (datos.Game, error) {
+					return repo.UpdateGameCategory(ctx, datos.UpdateGameCategoryParams{ID: id, Categoria: in.Categoria})
+				})
+			case validation.BulkAddTags:
+				err = addTags(ctx, repo, id, in.Tags)
+			default:
+				err = fmt.Errorf("acción masiva desconocida %q", in.Action)
+			}
+			if err != nil {
+				return fmt.Errorf("juego %d: %w", id, err)
+			}
+			result.Affected++
+		}
+		return nil
+	})
+	return result, err
+}
+
+// SetProgress cambia el estado de juego. Los estados terminados llevan fecha
+// de finalización: si no se indica se conserva la que ya tenía o, si no
+// tenía, se usa la de hoy. Los demás estados la borran.
+func (s *Games) SetProgress(ctx context.Context, gameID int32, status string, completedOn time.Time) (datos.GameProgress, error) {
+	var progress datos.GameProgress
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		arg := datos.UpsertGameProgressParams{GameID: gameID, PlayStatus: status}
+		if validation.IsFinished(status) {
+			if completedOn.IsZero() {
+				prev, err := repo.GetGameProgress(ctx, gameID)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return err
+				}
+				completedOn = prev.CompletedOn.Time
+				if !prev.CompletedOn.Valid {
+					y, m, d := time.Now().Date()
+					completedOn = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+				}
+			}
+			arg.CompletedOn = sql.NullTime{Time: completedOn, Valid: true}
+		}
+		var err error
+		progress, err = repo.UpsertGameProgress(ctx, arg)
+		return err
+	})
+	return progress, err
+}
+
+// RecordSession guarda una sesión de juego; sin EndedAt queda en curso. Si
+// el juego estaba en el backlog pasa a "playing".
+func (s *Games) RecordSession(ctx context.Context, arg datos.CreatePlaySessionParams) (datos.PlaySession, error) {
+	var session datos.PlaySession
+	err := s.store.RunInTx(ctx, func(repo repository.GameRepository) error {
+		var err error
+		session, err = repo.CreatePlaySession(ctx, arg)
+		if err != nil {
+			return err
+		}
+		progress, err := repo.GetGameProgress(ctx, arg.GameID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil && progress.PlayStatus != "backlog" {
+			return nil
+		}
+		_, err = repo.UpsertGameProgress(ctx, datos.UpsertGameProgressParams{GameID: arg.GameID, PlayStatus: "playing"})
+		return err
+	})
+	return session, err
+}
+
+// updateWithRevision ejecuta update y guarda el juego resultante como una
+// revisión nueva.
+func updateWithRevision(ctx context.Context, repo repository.GameRepository, update func() (datos.Game, error)) error {
+	game, err := update()
+	if err != nil {
+		return err
+	}
+	_, err = repo.CreateGameRevision(ctx, datos.CreateGameRevisionParams{
+		GameID:         game.ID,
+		Titulo:         game.Titulo,
+		Descripcion:    game.Descripcion,
+		Categoria:      game.Categoria,
+		Plataforma:     game.Plataforma,
+		Fecha:          game.Fecha,
+		FechaPrecision: game.FechaPrecision,
+		Estado:         game.Estado,
+		Imagen:         game.Imagen,
+	})
+	return err
+}
+
+// addTags crea las etiquetas que no existan y se las asigna al juego.
+func addTags(ctx context.Context, repo repository.GameRepository, gameID int32, tags []string) error {
+	for _, name := range tags {
+		tag, err := repo.UpsertTag(ctx, name)
+		if err != nil {
+			return fmt.Errorf("etiqueta %q: %w", name, err)
+		}
+		if err := repo.AddGameTag(ctx, datos.AddGameTagParams{GameID: gameID, TagID: tag.ID}); err != nil {
+			return fmt.Errorf("etiqueta %q: %w", name, err)
+		}
+	}
+	return nil
+}
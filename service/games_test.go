@@ -0,0 +1,256 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+	datos "tp-web/db/sqlc"
+	"tp-web/repository"
+	"tp-web/service"
+	"tp-web/validation"
+)
+
+func newGame(titulo string) datos.CreateGameParams {
+	return datos.CreateGameParams{
+		Titulo:         titulo,
+		Descripcion:    "Descripción de " + titulo,
+		Categoria:      "Accion",
+		Fecha:          time.Date(2024, 9, 10, 0, 0, 0, 0, time.UTC),
+		FechaPrecision: "day",
+		Estado:         "none",
+		Imagen:         "img/" + titulo + ".jpg",
+	}
+}
+
+// failingRevisions hace fallar CreateGameRevision dentro de las transacciones.
+type failingRevisions struct {
+	*repository.Memory
+}
+
+func (f failingRevisions) RunInTx(ctx context.Context, fn func(repository.GameRepository) error) error {
+	return f.Memory.RunInTx(ctx, func(repo repository.GameRepository) error {
+		return fn(failingRepo{repo})
+	})
+}
+
+type failingRepo struct {
+	repository.GameRepository
+}
+
+var errRevision = errors.New("revision failed")
+
+func (failingRepo) CreateGameRevision(context.Context, datos.CreateGameRevisionParams) (datos.GameRevision, error) {
+	return datos.GameRevision{}, errRevision
+}
+
+func TestCreateGame(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(store)
+
+	game, err := games.CreateGame(ctx, newGame("FIFA25"), []string{"deporte", "futbol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags, _ := store.ListGameTags(ctx, game.ID)
+	if len(tags) != 2 || tags[0] != "deporte" || tags[1] != "futbol" {
+		t.Errorf("tags = %v", tags)
+	}
+	if revisions, _ := store.ListGameRevisions(ctx, game.ID); len(revisions) != 1 {
+		t.Errorf("revisions = %+v, want the initial one", revisions)
+	}
+
+	// Las etiquetas existentes se reutilizan
+	other, err := games.CreateGame(ctx, newGame("PES"), []string{"futbol"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags, _ := store.ListGameTags(ctx, other.ID); len(tags) != 1 || tags[0] != "futbol" {
+		t.Errorf("tags = %v", tags)
+	}
+}
+
+func TestCreateGameRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(failingRevisions{store})
+
+	if _, err := games.CreateGame(ctx, newGame("FIFA25"), []string{"deporte"}); !errors.Is(err, errRevision) {
+		t.Fatalf("err = %v, want %v", err, errRevision)
+	}
+	if list, _ := store.ListGames(ctx); len(list) != 0 {
+		t.Errorf("game was kept after a failed transaction: %+v", list)
+	}
+	tag, _ := store.UpsertTag(ctx, "deporte")
+	if tag.ID != 1 {
+		t.Errorf("tag from the failed transaction was kept (new tag id = %d)", tag.ID)
+	}
+
+	// Sin la falla el mismo título se puede crear: el índice único no quedó ocupado
+	if _, err := service.NewGames(store).CreateGame(ctx, newGame("FIFA25"), nil); err != nil {
+		t.Errorf("create after rollback: %v", err)
+	}
+}
+
+func TestRevertGame(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(store)
+	game, err := games.CreateGame(ctx, newGame("FIFA25"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	changed := newGame("FIFA 25 Ultimate")
+	if _, err := store.UpdateGame(ctx, datos.UpdateGameParams{ID: game.ID, Titulo: changed.Titulo, Descripcion: changed.Descripcion, Categoria: changed.Categoria, Fecha: changed.Fecha, FechaPrecision: changed.FechaPrecision, Estado: "comprado", Imagen: changed.Imagen}); err != nil {
+		t.Fatal(err)
+	}
+
+	rev, err := games.RevertGame(ctx, game.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev.Revision != 2 || rev.RevertedFrom.Int32 != 1 {
+		t.Errorf("revision = %+v", rev)
+	}
+	if got, _ := store.GetGame(ctx, game.ID); got.Titulo != "FIFA25" || got.Estado != "none" {
+		t.Errorf("game after revert = %+v", got)
+	}
+
+	if _, err := games.RevertGame(ctx, game.ID, 9); err == nil {
+		t.Errorf("revert to a missing revision should fail")
+	}
+}
+
+func TestBulk(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(store)
+	fifa, _ := games.CreateGame(ctx, newGame("FIFA25"), nil)
+	pes, _ := games.CreateGame(ctx, newGame("PES"), nil)
+	ids := []int32{fifa.ID, pes.ID, 99}
+
+	res, err := games.Bulk(ctx, validation.BulkInput{Action: validation.BulkSetState, IDs: ids, Estado: "comprado"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Requested != 3 || res.Affected != 2 {
+		t.Errorf("result = %+v, want 2 of 3 (the missing game is skipped)", res)
+	}
+	if got, _ := store.GetGame(ctx, pes.ID); got.Estado != "comprado" {
+		t.Errorf("estado = %q", got.Estado)
+	}
+	if revisions, _ := store.ListGameRevisions(ctx, pes.ID); len(revisions) != 2 {
+		t.Errorf("revisions = %d, want the change recorded", len(revisions))
+	}
+
+	if _, err := games.Bulk(ctx, validation.BulkInput{Action: validation.BulkAddTags, IDs: ids, Tags: []string{"futbol"}}); err != nil {
+		t.Fatal(err)
+	}
+	if tags, _ := store.ListGameTags(ctx, fifa.ID); len(tags) != 1 || tags[0] != "futbol" {
+		t.Errorf("tags = %v", tags)
+	}
+
+	// Si falla con un juego no se modifica ninguno
+	_, err = service.NewGames(failingRevisions{store}).Bulk(ctx, validation.BulkInput{Action: validation.BulkSetCategory, IDs: ids, Categoria: "Deportes"})
+	if !errors.Is(err, errRevision) {
+		t.Fatalf("err = %v, want %v", err, errRevision)
+	}
+	if got, _ := store.GetGame(ctx, fifa.ID); got.Categoria != "Accion" {
+		t.Errorf("categoria = %q after a failed transaction", got.Categoria)
+	}
+
+	res, err = games.Bulk(ctx, validation.BulkInput{Action: validation.BulkDelete, IDs: ids})
+	if err != nil || res.Affected != 2 {
+		t.Fatalf("delete = %+v, %v", res, err)
+	}
+	if list, _ := store.ListGames(ctx); len(list) != 0 {
+		t.Errorf("games left = %+v", list)
+	}
+}
+
+func TestRecordPrice(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(store)
+	arg := newGame("Hades")
+	arg.Estado = "deseado"
+	hades, _ := games.CreateGame(ctx, arg, nil)
+	if _, err := store.UpsertPriceWatch(ctx, datos.UpsertPriceWatchParams{GameID: hades.ID, TargetCents: 1500, Currency: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Solo avisa al cruzar el objetivo, no mientras sigue por debajo ni en
+	// otra moneda
+	for _, step := range []struct {
+		cents    int32
+		currency string
+		notify   bool
+	}{
+		{2499, "USD", false},
+		{1499, "EUR", false},
+		{1499, "USD", true},
+		{1299, "USD", false},
+		{1999, "USD", false},
+		{1500, "USD", true},
+	} {
+		_, notified, err := games.RecordPrice(ctx, datos.CreatePriceObservationParams{GameID: hades.ID, PriceCents: step.cents, Currency: step.currency, Source: "manual"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if notified != step.notify {
+			t.Errorf("%d %s: notified = %v, want %v", step.cents, step.currency, notified, step.notify)
+		}
+	}
+	if count, _ := store.CountUnreadNotifications(ctx); count != 2 {
+		t.Errorf("unread = %d, want 2", count)
+	}
+	if observations, _ := store.ListPriceObservations(ctx, hades.ID); len(observations) != 6 {
+		t.Errorf("observations = %d, want 6", len(observations))
+	}
+}
+
+func TestPlayTracking(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemory()
+	games := service.NewGames(store)
+	hades, _ := games.CreateGame(ctx, newGame("Hades"), nil)
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	if _, err := games.RecordSession(ctx, datos.CreatePlaySessionParams{GameID: hades.ID, StartedAt: start}); err != nil {
+		t.Fatal(err)
+	}
+	if progress, _ := store.GetGameProgress(ctx, hades.ID); progress.PlayStatus != "playing" {
+		t.Errorf("play status = %q, want playing after the first session", progress.PlayStatus)
+	}
+	if _, err := games.RecordSession(ctx, datos.CreatePlaySessionParams{GameID: hades.ID, StartedAt: start}); err == nil {
+		t.Errorf("a second open session was accepted")
+	}
+
+	completed := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := games.SetProgress(ctx, hades.ID, "finished", completed); err != nil {
+		t.Fatal(err)
+	}
+	// Pasar al 100% sin fecha conserva la de finalización
+	progress, err := games.SetProgress(ctx, hades.ID, "completed", time.Time{})
+	if err != nil || !progress.CompletedOn.Time.Equal(completed) {
+		t.Errorf("progress = %+v, %v, want the completion date kept", progress, err)
+	}
+	// Una sesión nueva no cambia un juego que ya no está en el backlog
+	if _, err := store.EndPlaySession(ctx, datos.EndPlaySessionParams{GameID: hades.ID, EndedAt: sql.NullTime{Time: start.Add(time.Hour), Valid: true}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := games.RecordSession(ctx, datos.CreatePlaySessionParams{GameID: hades.ID, StartedAt: start.Add(24 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	if progress, _ := store.GetGameProgress(ctx, hades.ID); progress.PlayStatus != "completed" {
+		t.Errorf("play status = %q, want completed", progress.PlayStatus)
+	}
+
+	progress, err = games.SetProgress(ctx, hades.ID, "abandoned", time.Time{})
+	if err != nil || progress.CompletedOn.Valid {
+		t.Errorf("progress = %+v, %v, want no completion date", progress, err)
+	}
+}
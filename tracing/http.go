@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"tp-web/middleware"
+)
+
+// Middleware crea un span de servidor por petición, continuando la traza del
+// header traceparent si viene uno. Para nombrar el span con el patrón de
+// ruta ("GET /games/{id}") tiene que ir justo antes del mux, o solo con
+// middlewares que no cambien el *http.Request en el medio.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if sc, ok := parseTraceparent(r.Header.Get("traceparent")); ok {
+			ctx = context.WithValue(ctx, remoteKey{}, sc)
+		}
+		ctx, span := Start(ctx, r.Method, KindServer,
+			String("http.request.method", r.Method),
+			String("url.path", r.URL.Path),
+			Bool("htmx", r.Header.Get("HX-Request") == "true"),
+		)
+		defer span.End()
+
+		rec := middleware.NewStatusRecorder(w)
+		r = r.WithContext(ctx)
+		next.ServeHTTP(rec, r)
+
+		if r.Pattern != "" {
+			_, route, _ := strings.Cut(r.Pattern, " ")
+			span.SetName(r.Method + " " + route)
+			span.SetAttributes(String("http.route", route))
+		}
+		span.SetAttributes(Int("http.response.status_code", rec.Status))
+		if rec.Status >= http.StatusInternalServerError {
+			span.RecordError(httpError(rec.Status))
+		}
+	})
+}
+
+type httpError int
+
+func (e httpError) Error() string {
+	return http.StatusText(int(e))
+}
+
+// parseTraceparent lee un header W3C traceparent: 00-<trace id>-<span id>-<flags>.
+func parseTraceparent(h string) (spanContext, bool) {
+	var sc spanContext
+	parts := strings.Split(h, "-")
+	if len(parts) != 4 || parts[0] != "00" || len(parts[1]) != 32 || len(parts[2]) != 16 {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.traceID[:], []byte(parts[1])); err != nil {
+		return sc, false
+	}
+	if _, err := hex.Decode(sc.spanID[:], []byte(parts[2])); err != nil {
+		return sc, false
+	}
+	return sc, sc.traceID.IsValid() && sc.spanID.IsValid()
+}
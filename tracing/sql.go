@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	datos "tp-web/db/sqlc"
+)
+
+// WrapDB envuelve la conexión (o transacción) que usan las queries de sqlc
+// para crear un span de cliente por query, con el nombre de la query sqlc
+// ("GetGame") y el SQL como atributos.
+//
+// En QueryContext el span cubre la ejecución hasta que llegan las filas, no
+// la lectura que hace después el código generado.
+func WrapDB(db datos.DBTX) datos.DBTX {
+	return tracedDB{db}
+}
+
+type tracedDB struct {
+	db datos.DBTX
+}
+
+func (t tracedDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuery(ctx, query)
+	defer span.End()
+	res, err := t.db.ExecContext(ctx, query, args...)
+	span.RecordError(err)
+	return res, err
+}
+
+func (t tracedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startQuery(ctx, query)
+	defer span.End()
+	stmt, err := t.db.PrepareContext(ctx, query)
+	span.RecordError(err)
+	return stmt, err
+}
+
+func (t tracedDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuery(ctx, query)
+	defer span.End()
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	span.RecordError(err)
+	return rows, err
+}
+
+func (t tracedDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuery(ctx, query)
+	defer span.End()
+	row := t.db.QueryRowContext(ctx, query, args...)
+	// Errores de la query; sql.ErrNoRows recién aparece en Scan y no se marca
+	span.RecordError(row.Err())
+	return row
+}
+
+func startQuery(ctx context.Context, query string) (context.Context, *Span) {
+	name := queryName(query)
+	return Start(ctx, name, KindClient,
+		String("db.system", "postgresql"),
+		String("db.operation.name", name),
+		String("db.query.text", query),
+	)
+}
+
+// queryName saca el nombre de la query del comentario que agrega sqlc
+// ("-- name: GetGame :one"); si no lo tiene usa la primera palabra del SQL.
+func queryName(query string) string {
+	if rest, ok := strings.CutPrefix(query, "-- name: "); ok {
+		if name, _, ok := strings.Cut(rest, " "); ok {
+			return name
+		}
+	}
+	if fields := strings.Fields(query); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
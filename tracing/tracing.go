@@ -0,0 +1,299 @@
+// Package tracing registra trazas al estilo OpenTelemetry (spans con trace
+// id, span id y padre) sin depender del SDK: un span por petición HTTP y uno
+// por query, que se exportan en lotes por OTLP/HTTP.
+//
+// Mientras no se configure un Tracer con SetTracer, Start devuelve spans que
+// no registran nada, así que instrumentar el código no tiene costo.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type TraceID [16]byte
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+func (id TraceID) IsValid() bool  { return id != TraceID{} }
+
+type SpanID [8]byte
+
+func (id SpanID) String() string { return hex.EncodeToString(id[:]) }
+func (id SpanID) IsValid() bool  { return id != SpanID{} }
+
+// SpanKind sigue la numeración de OTLP.
+type SpanKind int
+
+const (
+	KindInternal SpanKind = 1
+	KindServer   SpanKind = 2
+	KindClient   SpanKind = 3
+)
+
+// Attribute es un par clave/valor de un span. Value puede ser string, int64,
+// float64 o bool.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func String(key, value string) Attribute    { return Attribute{key, value} }
+func Int(key string, value int) Attribute   { return Attribute{key, int64(value)} }
+func Bool(key string, value bool) Attribute { return Attribute{key, value} }
+
+// SpanData es un span terminado, listo para exportar.
+type SpanData struct {
+	TraceID      TraceID
+	SpanID       SpanID
+	ParentSpanID SpanID
+	Name         string
+	Kind         SpanKind
+	Start        time.Time
+	End          time.Time
+	Attributes   []Attribute
+	// Error es el mensaje si el span terminó con error, vacío si no.
+	Error string
+}
+
+// Exporter envía los spans terminados a algún lado.
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+// spanContext identifica un span, propio o recibido en traceparent.
+type spanContext struct {
+	traceID TraceID
+	spanID  SpanID
+}
+
+// Span es un span en curso. Sus métodos se pueden llamar sobre un span que no
+// registra (sin Tracer configurado) y no hacen nada.
+type Span struct {
+	tracer *Tracer
+	sc     spanContext
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// IsRecording indica si el span se va a exportar.
+func (s *Span) IsRecording() bool {
+	return s != nil && s.tracer != nil
+}
+
+// TraceID devuelve el id de la traza, o uno vacío si el span no registra.
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+	return s.sc.traceID
+}
+
+func (s *Span) SetName(name string) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	s.data.Name = name
+	s.mu.Unlock()
+}
+
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	s.data.Attributes = append(s.data.Attributes, attrs...)
+	s.mu.Unlock()
+}
+
+// RecordError marca el span como fallido. Un err nil no hace nada.
+func (s *Span) RecordError(err error) {
+	if err == nil || !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	s.data.Error = err.Error()
+	s.mu.Unlock()
+}
+
+// End termina el span y lo encola para exportar. Llamarlo más de una vez no
+// tiene efecto.
+func (s *Span) End() {
+	if !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	s.mu.Unlock()
+	s.tracer.enqueue(data)
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// SpanFromContext devuelve el span en curso guardado en ctx, o nil.
+func SpanFromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// parent devuelve el span padre para uno nuevo: el span en curso o, si no
+// hay, el recibido en el header traceparent.
+func parent(ctx context.Context) (spanContext, bool) {
+	if s := SpanFromContext(ctx); s.IsRecording() {
+		return s.sc, true
+	}
+	sc, ok := ctx.Value(remoteKey{}).(spanContext)
+	return sc, ok
+}
+
+var global atomic.Pointer[Tracer]
+
+// SetTracer configura el Tracer que usa Start; nil vuelve a no registrar nada.
+func SetTracer(t *Tracer) {
+	global.Store(t)
+}
+
+// Start crea un span hijo del que haya en ctx y devuelve un contexto que lo
+// contiene. Hay que llamar a End al terminar.
+func Start(ctx context.Context, name string, kind SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	t := global.Load()
+	if t == nil {
+		return ctx, &Span{}
+	}
+
+	s := &Span{tracer: t}
+	p, hasParent := parent(ctx)
+	if hasParent {
+		s.sc.traceID = p.traceID
+		s.data.ParentSpanID = p.spanID
+	} else {
+		rand.Read(s.sc.traceID[:])
+	}
+	rand.Read(s.sc.spanID[:])
+
+	s.data.TraceID = s.sc.traceID
+	s.data.SpanID = s.sc.spanID
+	s.data.Name = name
+	s.data.Kind = kind
+	s.data.Start = time.Now()
+	s.data.Attributes = attrs
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+// Límites del lote de exportación
+const (
+	maxQueue      = 2048
+	maxBatch      = 512
+	flushInterval = 5 * time.Second
+)
+
+// Tracer junta los spans terminados y los exporta en lotes cada
+// flushInterval o cuando se llena un lote. Si la cola se llena (por ejemplo
+// porque el colector no responde) los spans nuevos se descartan.
+type Tracer struct {
+	exporter Exporter
+
+	mu      sync.Mutex
+	queue   []SpanData
+	dropped int
+
+	flush    chan struct{}
+	done     chan struct{}
+	finished chan struct{}
+	once     sync.Once
+}
+
+// NewTracer crea un Tracer que exporta con exporter y arranca su goroutine de
+// exportación; hay que llamar a Shutdown al terminar.
+func NewTracer(exporter Exporter) *Tracer {
+	t := &Tracer{
+		exporter: exporter,
+		flush:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		finished: make(chan struct{}),
+	}
+	go t.loop()
+	return t
+}
+
+func (t *Tracer) enqueue(data SpanData) {
+	t.mu.Lock()
+	if len(t.queue) >= maxQueue {
+		t.dropped++
+		t.mu.Unlock()
+		return
+	}
+	t.queue = append(t.queue, data)
+	full := len(t.queue) >= maxBatch
+	t.mu.Unlock()
+
+	if full {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *Tracer) loop() {
+	defer close(t.finished)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.flush:
+		case <-t.done:
+			return
+		}
+		t.export(context.Background())
+	}
+}
+
+// export envía todo lo encolado, de a maxBatch spans.
+func (t *Tracer) export(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.queue
+	t.queue = nil
+	dropped := t.dropped
+	t.dropped = 0
+	t.mu.Unlock()
+
+	if dropped > 0 {
+		slog.Warn("Se descartaron spans porque la cola de exportación estaba llena", "descartados", dropped)
+	}
+	for len(spans) > 0 {
+		batch := spans[:min(len(spans), maxBatch)]
+		spans = spans[len(batch):]
+		if err := t.exporter.Export(ctx, batch); err != nil {
+			slog.Warn("Error al exportar spans", "spans", len(batch), "err", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown detiene la exportación periódica y exporta lo que quede encolado.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	t.once.Do(func() { close(t.done) })
+	select {
+	case <-t.finished:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return t.export(ctx)
+}
@@ -0,0 +1,182 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"tp-web/tracing"
+)
+
+// fakeDB implementa DBTX; solo se usa ExecContext.
+type fakeDB struct{ err error }
+
+func (f fakeDB) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return driver.RowsAffected(1), f.err
+}
+func (fakeDB) PrepareContext(context.Context, string) (*sql.Stmt, error) { return nil, nil }
+func (fakeDB) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, nil
+}
+func (fakeDB) QueryRowContext(context.Context, string, ...interface{}) *sql.Row { return nil }
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Kind         int    `json:"kind"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+func (s exportedSpan) attr(key string) any {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			for _, v := range a.Value {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// collector hace de colector OTLP/HTTP y guarda los spans que recibe.
+type collector struct {
+	mu      sync.Mutex
+	auth    string
+	service any
+	spans   map[string]exportedSpan
+}
+
+func newCollector(t *testing.T) (*collector, string) {
+	c := &collector{spans: map[string]exportedSpan{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var body struct {
+			ResourceSpans []struct {
+				Resource struct {
+					Attributes []struct {
+						Key   string         `json:"key"`
+						Value map[string]any `json:"value"`
+					} `json:"attributes"`
+				} `json:"resource"`
+				ScopeSpans []struct {
+					Spans []exportedSpan `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.auth = r.Header.Get("Authorization")
+		for _, rs := range body.ResourceSpans {
+			for _, a := range rs.Resource.Attributes {
+				if a.Key == "service.name" {
+					c.service = a.Value["stringValue"]
+				}
+			}
+			for _, ss := range rs.ScopeSpans {
+				for _, s := range ss.Spans {
+					c.spans[s.Name] = s
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return c, srv.URL
+}
+
+func TestHTTPAndSQLSpansExportedOverOTLP(t *testing.T) {
+	c, endpoint := newCollector(t)
+	headers, err := tracing.ParseHeaders("Authorization=Bearer secreto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer := tracing.NewTracer(tracing.NewOTLPExporter(endpoint, "tp-web-test", headers))
+	tracing.SetTracer(tracer)
+	t.Cleanup(func() { tracing.SetTracer(nil) })
+
+	db := tracing.WrapDB(fakeDB{})
+	failing := tracing.WrapDB(fakeDB{err: errors.New("connection refused")})
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /games/{id}", func(w http.ResponseWriter, r *http.Request) {
+		db.ExecContext(r.Context(), "-- name: DeleteGame :one\nDELETE FROM games WHERE id = $1", 1)
+	})
+	mux.HandleFunc("GET /boom", func(w http.ResponseWriter, r *http.Request) {
+		failing.ExecContext(r.Context(), "SELECT 1")
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := tracing.Middleware(mux)
+
+	const traceID, parentID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	req := httptest.NewRequest(http.MethodDelete, "/games/7", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	srv.ServeHTTP(httptest.NewRecorder(), req)
+	srv.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	if err := tracer.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.auth != "Bearer secreto" || c.service != "tp-web-test" {
+		t.Errorf("auth = %q, service.name = %v", c.auth, c.service)
+	}
+
+	server, ok := c.spans["DELETE /games/{id}"]
+	if !ok {
+		t.Fatalf("no server span; got %v", c.spans)
+	}
+	if server.TraceID != traceID || server.ParentSpanID != parentID || server.Kind != 2 {
+		t.Errorf("server span did not continue traceparent: %+v", server)
+	}
+	if server.attr("http.route") != "/games/{id}" || server.attr("http.response.status_code") != "200" {
+		t.Errorf("server span attributes = %+v", server.Attributes)
+	}
+
+	query := c.spans["DeleteGame"]
+	if query.TraceID != traceID || query.ParentSpanID != server.SpanID || query.Kind != 3 {
+		t.Errorf("query span is not a child of the server span: %+v", query)
+	}
+	if query.attr("db.system") != "postgresql" || query.Status.Code != 0 {
+		t.Errorf("query span = %+v", query)
+	}
+
+	boom := c.spans["GET /boom"]
+	if boom.Status.Code != 2 || boom.ParentSpanID != "" || boom.TraceID == traceID {
+		t.Errorf("500 span should be a new failed root: %+v", boom)
+	}
+	if failed := c.spans["SELECT"]; failed.Status.Code != 2 || failed.Status.Message != "connection refused" {
+		t.Errorf("failed query span = %+v", failed)
+	}
+}
+
+func TestNoopWithoutTracer(t *testing.T) {
+	tracing.SetTracer(nil)
+	ctx, span := tracing.Start(context.Background(), "x", tracing.KindInternal)
+	span.SetAttributes(tracing.String("k", "v"))
+	span.RecordError(errors.New("x"))
+	span.End()
+	if span.IsRecording() || tracing.SpanFromContext(ctx) != nil {
+		t.Errorf("span should not record without a tracer")
+	}
+}
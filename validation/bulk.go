@@ -0,0 +1,83 @@
+package validation
+
+import "strings"
+
+// Acciones masivas sobre la lista de juegos
+const (
+	BulkDelete      = "delete"
+	BulkSetState    = "set_state"
+	BulkSetCategory = "set_category"
+	BulkAddTags     = "add_tags"
+)
+
+// BulkEstados son los estados que se pueden poner en masa. "comprado" no está:
+// pasar un juego a comprado pide registrar la compra, de a un juego.
+var BulkEstados = []string{"none", "deseado"}
+
+// MaxBulkIDs limita cuántos juegos se pueden modificar en una sola acción.
+const MaxBulkIDs = 500
+
+// BulkInput es una acción sobre varios juegos de la lista. Según la acción
+// se usa Estado, Categoria o Tags; los demás se ignoran.
+type BulkInput struct {
+	Action    string   `json:"action"`
+	IDs       []int32  `json:"ids"`
+	Estado    string   `json:"state"`
+	Categoria string   `json:"category"`
+	Tags      []string `json:"tags"`
+}
+
+// Trim quita los espacios sobrantes, normaliza las etiquetas y saca los ids
+// repetidos.
+func (in BulkInput) Trim() BulkInput {
+	var ids []int32
+	seen := map[int32]bool{}
+	for _, id := range in.IDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return BulkInput{
+		Action:    strings.TrimSpace(in.Action),
+		IDs:       ids,
+		Estado:    strings.TrimSpace(in.Estado),
+		Categoria: strings.TrimSpace(in.Categoria),
+		Tags:      NormalizeTags(in.Tags),
+	}
+}
+
+// ValidateBulk revisa que haya juegos seleccionados y que la acción tenga los
+// datos que necesita.
+func ValidateBulk(in BulkInput) Errors {
+	errs := Errors{}
+
+	if len(in.IDs) == 0 {
+		errs["ids"] = "validation.bulk.ids.required"
+	} else if len(in.IDs) > MaxBulkIDs {
+		errs["ids"] = "validation.bulk.ids.too_many"
+	}
+
+	switch in.Action {
+	case BulkDelete:
+	case BulkSetState:
+		switch {
+		case in.Estado == "comprado":
+			errs["state"] = "validation.bulk.state.comprado"
+		case !isEstado(in.Estado):
+			errs["state"] = "validation.state.invalid"
+		}
+	case BulkSetCategory:
+		required(errs, "category", in.Categoria, "validation.category.required")
+		maxLength(errs, "category", in.Categoria, MaxCategoria, "validation.category.too_long")
+	case BulkAddTags:
+		if len(in.Tags) == 0 {
+			errs["tags"] = "validation.tags.required"
+		}
+		ValidateTags(errs, in.Tags)
+	default:
+		errs["action"] = "validation.bulk.action.invalid"
+	}
+
+	return errs
+}
@@ -0,0 +1,141 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Estados de juego admitidos por el CHECK de game_progress.play_status;
+// "completed" es el 100%.
+var PlayStatuses = []string{"backlog", "playing", "finished", "abandoned", "completed"}
+
+// IsFinished indica si el estado de juego cuenta como terminado y, por lo
+// tanto, lleva fecha de finalización.
+func IsFinished(status string) bool {
+	return status == "finished" || status == "completed"
+}
+
+// DateTimeLayout es el formato de los campos datetime-local del formulario.
+const DateTimeLayout = "2006-01-02T15:04"
+
+// MaxSessionHours es lo más que puede durar una sesión de juego.
+const MaxSessionHours = 24
+
+// ProgressInput es el estado de juego tal cual llega del formulario.
+type ProgressInput struct {
+	Status      string `json:"play_status"`
+	CompletedOn string `json:"completed_on"`
+}
+
+func (in ProgressInput) Trim() ProgressInput {
+	return ProgressInput{
+		Status:      strings.TrimSpace(in.Status),
+		CompletedOn: strings.TrimSpace(in.CompletedOn),
+	}
+}
+
+// ValidateProgress revisa el estado de juego y devuelve la fecha de
+// finalización, o el tiempo cero si no se cargó.
+func ValidateProgress(in ProgressInput) (time.Time, Errors) {
+	errs := Errors{}
+	if !slices.Contains(PlayStatuses, in.Status) {
+		errs["play_status"] = "validation.play_status.invalid"
+	}
+
+	var completed time.Time
+	if in.CompletedOn != "" {
+		d, err := time.Parse(DateLayout, in.CompletedOn)
+		switch {
+		case err != nil:
+			errs["completed_on"] = "validation.completed_on.invalid"
+		case !IsFinished(in.Status):
+			errs["completed_on"] = "validation.completed_on.not_finished"
+		default:
+			completed = d
+		}
+	}
+	return completed, errs
+}
+
+// SessionInput es una sesión de juego cargada a mano: el inicio y, para el
+// final, la hora en que terminó o cuántas horas duró. Hours en cero es que
+// no se cargaron. TZ es la zona horaria IANA del navegador, en la que se
+// escribieron las horas del formulario.
+type SessionInput struct {
+	Start string  `json:"started_at"`
+	End   string  `json:"ended_at"`
+	Hours float64 `json:"hours"`
+	Notes string  `json:"notes"`
+	TZ    string  `json:"tz"`
+}
+
+func (in SessionInput) Trim() SessionInput {
+	return SessionInput{
+		Start: strings.TrimSpace(in.Start),
+		End:   strings.TrimSpace(in.End),
+		Hours: in.Hours,
+		Notes: strings.TrimSpace(in.Notes),
+		TZ:    strings.TrimSpace(in.TZ),
+	}
+}
+
+// ValidateSession revisa la sesión y devuelve el inicio y el final. Las
+// horas del formulario se interpretan en la zona TZ o, si no vino, en loc;
+// desde JSON también se acepta RFC 3339. Si se cargan el final y las horas,
+// manda el final.
+func ValidateSession(in SessionInput, loc *time.Location) (time.Time, time.Time, Errors) {
+	errs := Errors{}
+	if in.TZ != "" {
+		tz, err := time.LoadLocation(in.TZ)
+		if err != nil {
+			errs["tz"] = "validation.tz.invalid"
+			return time.Time{}, time.Time{}, errs
+		}
+		loc = tz
+	}
+	start, ok := parseDateTime(in.Start, loc)
+	if in.Start == "" {
+		errs["started_at"] = "validation.started_at.required"
+	} else if !ok {
+		errs["started_at"] = "validation.started_at.invalid"
+	}
+
+	var end time.Time
+	switch {
+	case in.End != "":
+		if end, ok = parseDateTime(in.End, loc); !ok {
+			errs["ended_at"] = "validation.ended_at.invalid"
+		}
+	case in.Hours != 0:
+		switch {
+		case !(in.Hours > 0):
+			errs["hours"] = "validation.hours.invalid"
+		case in.Hours > MaxSessionHours:
+			errs["ended_at"] = "validation.ended_at.too_long"
+		default:
+			end = start.Add(time.Duration(in.Hours * float64(time.Hour)))
+		}
+	default:
+		errs["ended_at"] = "validation.ended_at.required"
+	}
+
+	if len(errs) == 0 {
+		switch d := end.Sub(start); {
+		case d < 0:
+			errs["ended_at"] = "validation.ended_at.before_start"
+		case d > MaxSessionHours*time.Hour:
+			errs["ended_at"] = "validation.ended_at.too_long"
+		}
+	}
+	maxLength(errs, "notes", in.Notes, MaxNotes, "validation.notes.too_long")
+	return start, end, errs
+}
+
+func parseDateTime(s string, loc *time.Location) (time.Time, bool) {
+	if t, err := time.ParseInLocation(DateTimeLayout, s, loc); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Límites de la tabla purchases (db/schema/schema.sql)
+const (
+	MaxStore = 50
+	MaxNotes = 255
+)
+
+// Formatos admitidos por el CHECK de la columna purchases.format
+var PurchaseFormats = []string{"physical", "digital"}
+
+// PurchaseInput son los datos de una compra tal cual llegan del formulario.
+// El precio viene como texto ("59.99" o "59,99") para no perder centavos.
+type PurchaseInput struct {
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+	Store    string `json:"store"`
+	Date     string `json:"purchase_date"`
+	Format   string `json:"format"`
+	Notes    string `json:"notes"`
+}
+
+// Trim quita los espacios sobrantes y pasa la moneda a mayúsculas.
+func (in PurchaseInput) Trim() PurchaseInput {
+	return PurchaseInput{
+		Price:    strings.TrimSpace(in.Price),
+		Currency: strings.ToUpper(strings.TrimSpace(in.Currency)),
+		Store:    strings.TrimSpace(in.Store),
+		Date:     strings.TrimSpace(in.Date),
+		Format:   strings.TrimSpace(in.Format),
+		Notes:    strings.TrimSpace(in.Notes),
+	}
+}
+
+// ValidatePurchase revisa la compra y devuelve el precio en centavos y la
+// fecha ya parseados. Si errs no está vacío esos valores no son válidos.
+func ValidatePurchase(in PurchaseInput) (int32, time.Time, Errors) {
+	cents, errs := ValidatePrice(PriceInput{Price: in.Price, Currency: in.Currency})
+
+	required(errs, "store", in.Store, "validation.store.required")
+	maxLength(errs, "store", in.Store, MaxStore, "validation.store.too_long")
+	maxLength(errs, "notes", in.Notes, MaxNotes, "validation.notes.too_long")
+
+	if !slices.Contains(PurchaseFormats, in.Format) {
+		errs["format"] = "validation.format.invalid"
+	}
+
+	var date time.Time
+	if in.Date == "" {
+		errs["purchase_date"] = "validation.purchase_date.required"
+	} else if d, err := time.Parse(DateLayout, in.Date); err != nil {
+		errs["purchase_date"] = "validation.purchase_date.invalid"
+	} else {
+		date = d
+	}
+
+	return cents, date, errs
+}
+
+// PriceInput es un precio suelto con su moneda: el precio objetivo de un
+// juego deseado o un precio observado cargado a mano.
+type PriceInput struct {
+	Price    string `json:"price"`
+	Currency string `json:"currency"`
+}
+
+func (in PriceInput) Trim() PriceInput {
+	return PriceInput{
+		Price:    strings.TrimSpace(in.Price),
+		Currency: strings.ToUpper(strings.TrimSpace(in.Currency)),
+	}
+}
+
+// ValidatePrice revisa el precio y la moneda y devuelve el precio en centavos.
+func ValidatePrice(in PriceInput) (int32, Errors) {
+	errs := Errors{}
+	var cents int32
+	if in.Price == "" {
+		errs["price"] = "validation.price.required"
+	} else if c, err := ParsePrice(in.Price); err != nil {
+		errs["price"] = "validation.price.invalid"
+	} else {
+		cents = c
+	}
+	if !isCurrency(in.Currency) {
+		errs["currency"] = "validation.currency.invalid"
+	}
+	return cents, errs
+}
+
+var errPrice = errors.New("precio inválido")
+
+// ParsePrice convierte un precio con hasta dos decimales, separados con punto
+// o coma, a centavos. No acepta separadores de miles ni valores negativos.
+func ParsePrice(s string) (int32, error) {
+	whole, frac, hasFrac := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	if whole == "" || len(frac) > 2 || (hasFrac && frac == "") {
+		return 0, errPrice
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return 0, errPrice
+		}
+	}
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil || n > math.MaxInt32 {
+		return 0, errPrice
+	}
+	return int32(n), nil
+}
+
+// isCurrency acepta un código ISO 4217: tres letras mayúsculas.
+func isCurrency(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
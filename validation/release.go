@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Precisiones de la fecha de salida admitidas por el CHECK de la columna
+// fecha_precision
+const (
+	PrecisionDay     = "day"
+	PrecisionMonth   = "month"
+	PrecisionQuarter = "quarter"
+	PrecisionYear    = "year"
+	PrecisionTBA     = "tba"
+)
+
+var Precisions = []string{PrecisionDay, PrecisionMonth, PrecisionQuarter, PrecisionYear, PrecisionTBA}
+
+// TBADate es la fecha que se guarda para los juegos sin fecha anunciada: la
+// más lejana posible, así quedan últimos al ordenar por fecha.
+var TBADate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Release es una fecha de salida ya validada. Date es el último día del
+// período que indica Precision, que es lo que se guarda en la columna fecha.
+type Release struct {
+	Date      time.Time
+	Precision string
+}
+
+// ParseRelease interpreta la fecha de salida con la precisión indicada. Con
+// precisión day se pide AAAA-MM-DD; con month y quarter también se acepta
+// AAAA-MM y con year también AAAA, así que el día o el mes de más se
+// ignoran. Con tba la fecha se ignora.
+//
+// Si precision está vacía se deduce del formato: AAAA-MM-DD, AAAA-MM,
+// AAAA-QN (trimestre), AAAA o TBA. Devuelve la clave del error, o "" si la
+// fecha es válida.
+func ParseRelease(value, precision string) (Release, string) {
+	if precision == "" {
+		return inferRelease(value)
+	}
+	if !slices.Contains(Precisions, precision) {
+		return Release{}, "validation.release_precision.invalid"
+	}
+	if precision == PrecisionTBA {
+		return Release{Date: TBADate, Precision: PrecisionTBA}, ""
+	}
+	if value == "" {
+		return Release{}, "validation.release_date.required"
+	}
+
+	layouts := map[string][]string{
+		PrecisionDay:     {DateLayout},
+		PrecisionMonth:   {DateLayout, "2006-01"},
+		PrecisionQuarter: {DateLayout, "2006-01"},
+		PrecisionYear:    {DateLayout, "2006-01", "2006"},
+	}[precision]
+	for _, layout := range layouts {
+		if d, err := time.Parse(layout, value); err == nil {
+			return Release{Date: periodEnd(d, precision), Precision: precision}, ""
+		}
+	}
+	return Release{}, "validation.release_date.invalid"
+}
+
+// inferRelease deduce la precisión del formato de la fecha.
+func inferRelease(value string) (Release, string) {
+	switch {
+	case value == "":
+		return Release{}, "validation.release_date.required"
+	case strings.EqualFold(value, PrecisionTBA):
+		return ParseRelease("", PrecisionTBA)
+	case len(value) == len("2006-Q1") && strings.EqualFold(value[4:6], "-Q"):
+		var year, quarter int
+		if _, err := fmt.Sscanf(strings.ToUpper(value), "%4d-Q%1d", &year, &quarter); err != nil || quarter < 1 || quarter > 4 {
+			return Release{}, "validation.release_date.invalid"
+		}
+		return ParseRelease(fmt.Sprintf("%04d-%02d", year, quarter*3), PrecisionQuarter)
+	case len(value) == len("2006"):
+		return ParseRelease(value, PrecisionYear)
+	case len(value) == len("2006-01"):
+		return ParseRelease(value, PrecisionMonth)
+	default:
+		return ParseRelease(value, PrecisionDay)
+	}
+}
+
+// periodEnd devuelve el último día del mes, trimestre o año de d.
+func periodEnd(d time.Time, precision string) time.Time {
+	switch precision {
+	case PrecisionMonth:
+		return time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	case PrecisionQuarter:
+		last := (d.Month()-1)/3*3 + 3
+		return time.Date(d.Year(), last+1, 0, 0, 0, 0, 0, time.UTC)
+	case PrecisionYear:
+		return time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return d
+}
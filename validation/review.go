@@ -0,0 +1,40 @@
+package validation
+
+import "strings"
+
+// Límites de la tabla reviews (db/schema/schema.sql)
+const (
+	MaxReviewer   = 50
+	MaxReviewBody = 5000
+	MinRating     = 1
+	MaxRating     = 10
+)
+
+// ReviewInput es una reseña tal cual llega del formulario. El cuerpo es
+// Markdown y se guarda sin convertir; Rating en cero es que no se puntuó.
+type ReviewInput struct {
+	Reviewer string `json:"reviewer"`
+	Rating   int32  `json:"rating"`
+	Body     string `json:"body"`
+}
+
+func (in ReviewInput) Trim() ReviewInput {
+	return ReviewInput{
+		Reviewer: strings.TrimSpace(in.Reviewer),
+		Rating:   in.Rating,
+		Body:     strings.TrimSpace(in.Body),
+	}
+}
+
+// ValidateReview revisa la reseña.
+func ValidateReview(in ReviewInput) Errors {
+	errs := Errors{}
+	required(errs, "reviewer", in.Reviewer, "validation.reviewer.required")
+	maxLength(errs, "reviewer", in.Reviewer, MaxReviewer, "validation.reviewer.too_long")
+	maxLength(errs, "body", in.Body, MaxReviewBody, "validation.body.too_long")
+
+	if in.Rating < MinRating || in.Rating > MaxRating {
+		errs["rating"] = "validation.rating.invalid"
+	}
+	return errs
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+)
+
+// MaxSeriesName es el largo de la columna series.name (db/schema/schema.sql).
+const MaxSeriesName = 100
+
+// Ediciones admitidas por el CHECK de game_editions.edition, en el orden en
+// que se muestran.
+var Editions = []string{"standard", "deluxe", "goty", "ultimate", "collector"}
+
+// SeriesInput es una serie tal cual llega del formulario.
+type SeriesInput struct {
+	Name string `json:"name"`
+}
+
+func (in SeriesInput) Trim() SeriesInput {
+	return SeriesInput{Name: strings.TrimSpace(in.Name)}
+}
+
+// ValidateSeries revisa el nombre de la serie.
+func ValidateSeries(in SeriesInput) Errors {
+	errs := Errors{}
+	required(errs, "name", in.Name, "validation.series_name.required")
+	maxLength(errs, "name", in.Name, MaxSeriesName, "validation.series_name.too_long")
+	return errs
+}
+
+// SeriesEntryInput es la serie a la que pertenece un juego y su lugar en
+// ella, tal cual llegan del formulario. En cero es que no se cargaron.
+type SeriesEntryInput struct {
+	SeriesID int32 `json:"series_id"`
+	Position int32 `json:"position"`
+}
+
+func (in SeriesEntryInput) Trim() SeriesEntryInput {
+	return in
+}
+
+// ValidateSeriesEntry revisa la serie y la posición de la entrega.
+func ValidateSeriesEntry(in SeriesEntryInput) Errors {
+	errs := Errors{}
+	if in.SeriesID <= 0 {
+		errs["series_id"] = "validation.series_id.invalid"
+	}
+	if in.Position <= 0 {
+		errs["position"] = "validation.position.invalid"
+	}
+	return errs
+}
+
+// EditionInput es una edición de un juego tal cual llega del formulario.
+type EditionInput struct {
+	Edition string `json:"edition"`
+	Owned   bool   `json:"owned"`
+}
+
+func (in EditionInput) Trim() EditionInput {
+	return EditionInput{Edition: strings.ToLower(strings.TrimSpace(in.Edition)), Owned: in.Owned}
+}
+
+// ValidateEdition revisa que la edición sea una de Editions.
+func ValidateEdition(in EditionInput) Errors {
+	errs := Errors{}
+	if !slices.Contains(Editions, in.Edition) {
+		errs["edition"] = "validation.edition.invalid"
+	}
+	return errs
+}
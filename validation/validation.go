@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"strings"
+	"tp-web/i18n"
+	"unicode/utf8"
+)
+
+// Límites de longitud de las columnas de la tabla games (db/schema/schema.sql)
+const (
+	MaxTitulo      = 150
+	MaxDescripcion = 255
+	MaxCategoria   = 50
+	MaxPlataforma  = 30
+	MaxImagen      = 50
+
+	// Columna tags.name y cantidad de etiquetas por juego
+	MaxTag  = 30
+	MaxTags = 10
+)
+
+// Formato de fecha que envía el <input type="date"> del formulario
+const DateLayout = "2006-01-02"
+
+// Estados admitidos por el CHECK de la columna estado
+var Estados = []string{"none", "deseado", "comprado"}
+
+// GameInput contiene los datos de un juego tal cual llegan del cliente,
+// antes de validarlos. Se usa también para volver a mostrar el formulario.
+// Los nombres JSON coinciden con los del formulario HTML, igual que las
+// claves de Errors.
+type GameInput struct {
+	Titulo      string `json:"title"`
+	Descripcion string `json:"description"`
+	Categoria   string `json:"category"`
+	// Plataforma es opcional; el título no se repite dentro de una plataforma.
+	Plataforma string `json:"platform"`
+	Fecha      string `json:"release_date"`
+	// Precision es la precisión de la fecha (day, month, quarter, year o
+	// tba); vacía se deduce del formato de Fecha.
+	Precision string `json:"release_precision"`
+	Estado    string `json:"state"`
+	Imagen    string `json:"-"`
+	// Tags son las etiquetas del juego; en el formulario van separadas por coma.
+	Tags []string `json:"tags"`
+
+	// MetadataID es el juego del catálogo elegido en la búsqueda; al guardar
+	// se descarga su portada.
+	MetadataID string `json:"metadata_id"`
+
+	// ConfirmDuplicate indica que el usuario ya vio los juegos con título
+	// parecido y quiere guardarlo igual.
+	ConfirmDuplicate bool `json:"confirm_duplicate"`
+}
+
+// Errors asocia el nombre de un campo del formulario con la clave del
+// mensaje de error en el catálogo de i18n.
+type Errors map[string]string
+
+// Has indica si el campo tiene algún error.
+func (e Errors) Has(field string) bool {
+	_, ok := e[field]
+	return ok
+}
+
+// Get devuelve la clave del mensaje de error del campo, o "" si no tiene.
+func (e Errors) Get(field string) string {
+	return e[field]
+}
+
+// Trim quita los espacios sobrantes de todos los campos.
+func (in GameInput) Trim() GameInput {
+	return GameInput{
+		Titulo:      strings.TrimSpace(in.Titulo),
+		Descripcion: strings.TrimSpace(in.Descripcion),
+		Categoria:   strings.TrimSpace(in.Categoria),
+		Plataforma:  strings.TrimSpace(in.Plataforma),
+		Fecha:       strings.TrimSpace(in.Fecha),
+		Precision:   strings.ToLower(strings.TrimSpace(in.Precision)),
+		Estado:      strings.TrimSpace(in.Estado),
+		Imagen:      strings.TrimSpace(in.Imagen),
+		Tags:        NormalizeTags(in.Tags),
+		MetadataID:  strings.TrimSpace(in.MetadataID),
+
+		ConfirmDuplicate: in.ConfirmDuplicate,
+	}
+}
+
+// SplitTags separa las etiquetas escritas en un campo de texto ("rpg, indie").
+func SplitTags(s string) []string {
+	return NormalizeTags(strings.Split(s, ","))
+}
+
+// NormalizeTags pasa las etiquetas a minúsculas sin espacios sobrantes y
+// quita las vacías y las repetidas, conservando el orden.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		t = strings.Join(strings.Fields(strings.ToLower(t)), " ")
+		if t != "" && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// ValidateTags revisa la cantidad y el largo de las etiquetas.
+func ValidateTags(errs Errors, tags []string) {
+	if len(tags) > MaxTags {
+		errs["tags"] = "validation.tags.too_many"
+		return
+	}
+	for _, t := range tags {
+		maxLength(errs, "tags", t, MaxTag, "validation.tags.too_long")
+	}
+}
+
+// ValidateGame revisa cada campo contra las restricciones del esquema y
+// devuelve la fecha de salida ya parseada. Si errs no está vacío la fecha no
+// es válida.
+func ValidateGame(in GameInput) (Release, Errors) {
+	errs := Errors{}
+
+	required(errs, "title", in.Titulo, "validation.title.required")
+	maxLength(errs, "title", in.Titulo, MaxTitulo, "validation.title.too_long")
+
+	required(errs, "description", in.Descripcion, "validation.description.required")
+	maxLength(errs, "description", in.Descripcion, MaxDescripcion, "validation.description.too_long")
+
+	required(errs, "category", in.Categoria, "validation.category.required")
+	maxLength(errs, "category", in.Categoria, MaxCategoria, "validation.category.too_long")
+
+	maxLength(errs, "platform", in.Plataforma, MaxPlataforma, "validation.platform.too_long")
+
+	maxLength(errs, "image", in.Imagen, MaxImagen, "validation.image.too_long")
+
+	if !isEstado(in.Estado) {
+		errs["state"] = "validation.state.invalid"
+	}
+
+	ValidateTags(errs, in.Tags)
+
+	release, key := ParseRelease(in.Fecha, in.Precision)
+	if key == "validation.release_precision.invalid" {
+		errs["release_precision"] = key
+	} else if key != "" {
+		errs["release_date"] = key
+	}
+
+	return release, errs
+}
+
+// Translate devuelve los mensajes de error ya traducidos al idioma pedido.
+func (e Errors) Translate(lang string) map[string]string {
+	out := make(map[string]string, len(e))
+	for field, key := range e {
+		out[field] = i18n.Translate(lang, key)
+	}
+	return out
+}
+
+func required(errs Errors, field, value, key string) {
+	if value == "" && !errs.Has(field) {
+		errs[field] = key
+	}
+}
+
+func maxLength(errs Errors, field, value string, max int, key string) {
+	if utf8.RuneCountInString(value) > max && !errs.Has(field) {
+		errs[field] = key
+	}
+}
+
+func isEstado(estado string) bool {
+	for _, e := range Estados {
+		if e == estado {
+			return true
+		}
+	}
+	return false
+}